@@ -20,6 +20,13 @@ type ExprVisitor interface {
 	VisitSuperExpr(*SuperExpr) (any, error)
 	VisitSetKeyExpr(*SetKeyExpr) (any, error)
 	VisitGetKeyExpr(*GetKeyExpr) (any, error)
+	VisitListExpr(*ListExpr) (any, error)
+	VisitMapExpr(*MapExpr) (any, error)
+	VisitInterpolationExpr(*InterpolationExpr) (any, error)
+	VisitCompoundAssignExpr(*CompoundAssignExpr) (any, error)
+	VisitConditionalExpr(*ConditionalExpr) (any, error)
+	VisitSpreadExpr(*SpreadExpr) (any, error)
+	VisitDestructureExpr(*DestructureExpr) (any, error)
 }
 
 type Expr interface {
@@ -92,6 +99,7 @@ type CallExpr struct {
 	Callee Expr
 	Paren scanner.Token
 	Args []Expr
+	Names []*scanner.Token
 }
 
 func (c *CallExpr) Accept(visitor ExprVisitor) (any, error) {
@@ -100,6 +108,8 @@ func (c *CallExpr) Accept(visitor ExprVisitor) (any, error) {
 
 type LambdaExpr struct {
 	Params []scanner.Token
+	Defaults []Expr
+	Variadic bool
 	Body []Stmt
 }
 
@@ -164,3 +174,72 @@ func (g *GetKeyExpr) Accept(visitor ExprVisitor) (any, error) {
 	return visitor.VisitGetKeyExpr(g)
 }
 
+type ListExpr struct {
+	Bracket scanner.Token
+	Elements []Expr
+}
+
+func (l *ListExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitListExpr(l)
+}
+
+type MapExpr struct {
+	Brace scanner.Token
+	Keys []Expr
+	Values []Expr
+}
+
+func (m *MapExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitMapExpr(m)
+}
+
+type InterpolationExpr struct {
+	Token scanner.Token
+	Parts []Expr
+}
+
+func (i *InterpolationExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitInterpolationExpr(i)
+}
+
+type CompoundAssignExpr struct {
+	Target Expr
+	Operator scanner.Token
+	Value Expr
+	Postfix bool
+}
+
+func (c *CompoundAssignExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitCompoundAssignExpr(c)
+}
+
+type ConditionalExpr struct {
+	Condition Expr
+	Question scanner.Token
+	Then Expr
+	Else Expr
+}
+
+func (c *ConditionalExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitConditionalExpr(c)
+}
+
+type SpreadExpr struct {
+	Ellipsis scanner.Token
+	Value Expr
+}
+
+func (s *SpreadExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitSpreadExpr(s)
+}
+
+type DestructureExpr struct {
+	Target *ListExpr
+	Equals scanner.Token
+	Value Expr
+}
+
+func (d *DestructureExpr) Accept(visitor ExprVisitor) (any, error) {
+	return visitor.VisitDestructureExpr(d)
+}
+
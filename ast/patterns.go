@@ -0,0 +1,97 @@
+package ast
+
+import "github.com/Valeron93/crafting-interpreters/scanner"
+
+// Pattern is tested against a value by a match statement or a destructuring let,
+// binding names of the value's parts when it matches
+type Pattern interface {
+	pattern()
+}
+
+// LiteralPattern matches values equal to Value
+type LiteralPattern struct {
+	Token scanner.Token
+	Value any
+}
+
+// WildcardPattern `_` matches anything without binding it
+type WildcardPattern struct {
+	Token scanner.Token
+}
+
+// BindingPattern matches anything and binds it to Name
+type BindingPattern struct {
+	Name scanner.Token
+}
+
+// ClassPattern `Dog(name)` matches instances of Class or its subclasses,
+// binding the listed fields of the instance to variables of the same name
+type ClassPattern struct {
+	Class  *VarExpr
+	Fields []scanner.Token
+}
+
+// ListPattern `[a, b, ...rest]` matches lists with an element for every pattern,
+// extra elements are only allowed when they are collected into Rest
+type ListPattern struct {
+	Bracket  scanner.Token
+	Elements []Pattern
+	Rest     *scanner.Token
+}
+
+// ObjectPattern `{name, age: years}` matches the map keys or the properties
+// of an object against the pattern of each key
+type ObjectPattern struct {
+	Brace  scanner.Token
+	Keys   []scanner.Token
+	Values []Pattern
+}
+
+// DefaultPattern `pattern = default` is used in list and object patterns,
+// Default is matched against Pattern when the element or key is missing
+type DefaultPattern struct {
+	Pattern Pattern
+	Default Expr
+}
+
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expr
+	Body    Stmt
+}
+
+func (*LiteralPattern) pattern()  {}
+func (*WildcardPattern) pattern() {}
+func (*BindingPattern) pattern()  {}
+func (*ClassPattern) pattern()    {}
+func (*ListPattern) pattern()     {}
+func (*ObjectPattern) pattern()   {}
+func (*DefaultPattern) pattern()  {}
+
+// Bindings returns the names bound by pattern when it matches
+func Bindings(pattern Pattern) []scanner.Token {
+	switch pattern := pattern.(type) {
+	case *BindingPattern:
+		return []scanner.Token{pattern.Name}
+	case *ClassPattern:
+		return pattern.Fields
+	case *ListPattern:
+		names := make([]scanner.Token, 0)
+		for _, element := range pattern.Elements {
+			names = append(names, Bindings(element)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, *pattern.Rest)
+		}
+		return names
+	case *ObjectPattern:
+		names := make([]scanner.Token, 0)
+		for _, value := range pattern.Values {
+			names = append(names, Bindings(value)...)
+		}
+		return names
+	case *DefaultPattern:
+		return Bindings(pattern.Pattern)
+	}
+	return nil
+}
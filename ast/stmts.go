@@ -14,6 +14,16 @@ type StmtVisitor interface {
 	VisitReturnStmt(*ReturnStmt) (any, error)
 	VisitClassDeclStmt(*ClassDeclStmt) (any, error)
 	VisitMethodDeclStmt(*MethodDeclStmt) (any, error)
+	VisitBreakStmt(*BreakStmt) (any, error)
+	VisitContinueStmt(*ContinueStmt) (any, error)
+	VisitThrowStmt(*ThrowStmt) (any, error)
+	VisitTryStmt(*TryStmt) (any, error)
+	VisitImportStmt(*ImportStmt) (any, error)
+	VisitExportStmt(*ExportStmt) (any, error)
+	VisitMatchStmt(*MatchStmt) (any, error)
+	VisitForInStmt(*ForInStmt) (any, error)
+	VisitDestructureStmt(*DestructureStmt) (any, error)
+	VisitTraitDeclStmt(*TraitDeclStmt) (any, error)
 }
 
 type Stmt interface {
@@ -58,6 +68,7 @@ func (b *BlockStmt) Accept(visitor StmtVisitor) (any, error) {
 type WhileStmt struct {
 	Condition Expr
 	Body Stmt
+	Increment Expr
 }
 
 func (w *WhileStmt) Accept(visitor StmtVisitor) (any, error) {
@@ -67,7 +78,10 @@ func (w *WhileStmt) Accept(visitor StmtVisitor) (any, error) {
 type FuncDeclStmt struct {
 	Name scanner.Token
 	Params []scanner.Token
+	Defaults []Expr
+	Variadic bool
 	Body []Stmt
+	Doc string
 }
 
 func (f *FuncDeclStmt) Accept(visitor StmtVisitor) (any, error) {
@@ -87,6 +101,10 @@ type ClassDeclStmt struct {
 	Name scanner.Token
 	Methods []*MethodDeclStmt
 	Superclass *VarExpr
+	Doc string
+	Fields []*VarStmt
+	StaticFields []*VarStmt
+	Traits []*VarExpr
 }
 
 func (c *ClassDeclStmt) Accept(visitor StmtVisitor) (any, error) {
@@ -96,9 +114,111 @@ func (c *ClassDeclStmt) Accept(visitor StmtVisitor) (any, error) {
 type MethodDeclStmt struct {
 	Func *FuncDeclStmt
 	Static bool
+	Getter bool
+	Setter bool
+	Abstract bool
 }
 
 func (m *MethodDeclStmt) Accept(visitor StmtVisitor) (any, error) {
 	return visitor.VisitMethodDeclStmt(m)
 }
 
+type BreakStmt struct {
+	Keyword scanner.Token
+}
+
+func (b *BreakStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitBreakStmt(b)
+}
+
+type ContinueStmt struct {
+	Keyword scanner.Token
+}
+
+func (c *ContinueStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitContinueStmt(c)
+}
+
+type ThrowStmt struct {
+	Keyword scanner.Token
+	Value Expr
+}
+
+func (t *ThrowStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitThrowStmt(t)
+}
+
+type TryStmt struct {
+	Keyword scanner.Token
+	Body []Stmt
+	CatchName *scanner.Token
+	Catch []Stmt
+	Finally []Stmt
+}
+
+func (t *TryStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitTryStmt(t)
+}
+
+type ImportStmt struct {
+	Keyword scanner.Token
+	Path scanner.Token
+	Alias *scanner.Token
+	Names []scanner.Token
+}
+
+func (i *ImportStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitImportStmt(i)
+}
+
+type ExportStmt struct {
+	Keyword scanner.Token
+	Decl Stmt
+}
+
+func (e *ExportStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitExportStmt(e)
+}
+
+type MatchStmt struct {
+	Keyword scanner.Token
+	Value Expr
+	Arms []*MatchArm
+}
+
+func (m *MatchStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitMatchStmt(m)
+}
+
+type ForInStmt struct {
+	Keyword scanner.Token
+	Name scanner.Token
+	Iterable Expr
+	Body Stmt
+}
+
+func (f *ForInStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitForInStmt(f)
+}
+
+type DestructureStmt struct {
+	Keyword scanner.Token
+	Pattern Pattern
+	Init Expr
+}
+
+func (d *DestructureStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitDestructureStmt(d)
+}
+
+type TraitDeclStmt struct {
+	Name scanner.Token
+	Methods []*FuncDeclStmt
+	Required []*FuncDeclStmt
+	Doc string
+}
+
+func (t *TraitDeclStmt) Accept(visitor StmtVisitor) (any, error) {
+	return visitor.VisitTraitDeclStmt(t)
+}
+
@@ -0,0 +1,172 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// missingArgument takes the place of arguments left out of a call,
+// the function evaluates the default value of the parameter instead
+var missingArgument = &Sentinel{Name: "missing argument"}
+
+// keywordArgument is an argument passed to a call as `name: value`
+type keywordArgument struct {
+	Name  scanner.Token
+	Value any
+}
+
+// evalArguments evaluates the arguments of a call,
+// `...iterable` arguments are spread into positional ones
+func (i *Interpreter) evalArguments(expr *ast.CallExpr) ([]any, []keywordArgument, error) {
+	args := make([]any, 0, len(expr.Args))
+	keywords := make([]keywordArgument, 0)
+
+	for idx, arg := range expr.Args {
+		if spread, ok := arg.(*ast.SpreadExpr); ok {
+			iterable, err := i.Eval(spread.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			err = i.iterate(spread.Ellipsis, iterable, func(value any) (bool, error) {
+				args = append(args, value)
+				return true, nil
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		value, err := i.Eval(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if name := expr.Names[idx]; name != nil {
+			keywords = append(keywords, keywordArgument{Name: *name, Value: value})
+		} else {
+			args = append(args, value)
+		}
+	}
+
+	return args, keywords, nil
+}
+
+// declaration returns the declaration of the parameters of f, ok is false for
+// callables without named parameters, e.g. native functions
+func declaration(f Callable) (*ast.FuncDeclStmt, bool) {
+	switch f := f.(type) {
+	case *CallableObject:
+		return f.Declaration, true
+	case *Class:
+		constructor := f.FindConstructor()
+		if constructor == nil {
+			return &ast.FuncDeclStmt{}, true
+		}
+		return declaration(constructor)
+	}
+	return nil, false
+}
+
+// positionalParams returns the number of parameters of decl excluding the rest parameter,
+// and how many of them have no default value
+func positionalParams(decl *ast.FuncDeclStmt) (int, int) {
+	positional := len(decl.Params)
+	if decl.Variadic {
+		positional--
+	}
+
+	required := 0
+	for required < positional && decl.Defaults[required] == nil {
+		required++
+	}
+	return positional, required
+}
+
+// acceptedArguments describes how many arguments a function declared by decl accepts
+func acceptedArguments(decl *ast.FuncDeclStmt) string {
+	positional, required := positionalParams(decl)
+	switch {
+	case decl.Variadic:
+		return fmt.Sprintf("at least %v", required)
+	case required != positional:
+		return fmt.Sprintf("%v to %v", required, positional)
+	default:
+		return fmt.Sprint(positional)
+	}
+}
+
+// bindArguments puts the positional and keyword arguments of a call in the order
+// of the parameters of decl, extra arguments for the rest parameter go last
+func (i *Interpreter) bindArguments(paren scanner.Token, callee any, decl *ast.FuncDeclStmt, args []any, keywords []keywordArgument) ([]any, error) {
+	positional, required := positionalParams(decl)
+	accepted := acceptedArguments(decl)
+
+	if len(args) > positional && !decl.Variadic {
+		return nil, util.ReportErrorOnToken(paren, "function '%v' expects %v arguments, got: %v", callee, accepted, len(args)+len(keywords))
+	}
+
+	bound := make([]any, positional)
+	for idx := range bound {
+		if idx < len(args) {
+			bound[idx] = args[idx]
+		} else {
+			bound[idx] = missingArgument
+		}
+	}
+	if len(args) > positional {
+		bound = append(bound, args[positional:]...)
+	}
+
+	for _, keyword := range keywords {
+		position := -1
+		for idx, param := range decl.Params[:positional] {
+			if param.Lexeme == keyword.Name.Lexeme {
+				position = idx
+				break
+			}
+		}
+		if position < 0 {
+			return nil, util.ReportErrorOnToken(keyword.Name, "function '%v' has no parameter '%v'", callee, keyword.Name.Lexeme)
+		}
+		if bound[position] != missingArgument {
+			return nil, util.ReportErrorOnToken(keyword.Name, "argument '%v' was passed more than once", keyword.Name.Lexeme)
+		}
+		bound[position] = keyword.Value
+	}
+
+	missing := make([]string, 0)
+	for idx := range required {
+		if bound[idx] == missingArgument {
+			missing = append(missing, fmt.Sprintf("'%v'", decl.Params[idx].Lexeme))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, util.ReportErrorOnToken(paren, "function '%v' expects %v arguments, missing: %v", callee, accepted, strings.Join(missing, ", "))
+	}
+
+	return bound, nil
+}
+
+// callSpecial calls a special method like `__add` or `__next` with args,
+// errors about the number of arguments are reported on token
+func (i *Interpreter) callSpecial(token scanner.Token, name string, method Callable, args ...any) (any, error) {
+	if decl, ok := declaration(method); ok {
+		positional, required := positionalParams(decl)
+		if len(args) < required || len(args) > positional && !decl.Variadic {
+			return nil, util.ReportErrorOnToken(token, "method '%v' expects %v arguments, got: %v", name, acceptedArguments(decl), len(args))
+		}
+
+		var err error
+		args, err = i.bindArguments(token, method, decl, args, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return method.Call(i, args)
+}
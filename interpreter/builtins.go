@@ -2,7 +2,12 @@ package interpreter
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
+
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
 )
 
 type ClockFunction struct {
@@ -20,12 +25,16 @@ func (c *ClockFunction) Bind(this any) Callable {
 	return c
 }
 
+func (c *ClockFunction) String() string {
+	return "<native fn clock>"
+}
+
 type PrintFunction struct {
 }
 
 func (p *PrintFunction) Call(i *Interpreter, args []any) (any, error) {
 	for _, arg := range args {
-		fmt.Printf("%v", arg)
+		fmt.Print(stringify(arg))
 	}
 	fmt.Println()
 	return nil, nil
@@ -38,3 +47,107 @@ func (p *PrintFunction) Arity() (int, bool) {
 func (p *PrintFunction) Bind(this any) Callable {
 	return p
 }
+
+func (p *PrintFunction) String() string {
+	return "<native fn print>"
+}
+
+// stringify converts a value to text the way print and string interpolation show it
+func stringify(value any) string {
+	// whole floats keep '.0', so they can be told apart from ints
+	if number, ok := value.(float64); ok && number == math.Trunc(number) && math.Abs(number) < 1e21 {
+		return strconv.FormatFloat(number, 'f', 1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+type NativeFunction struct {
+	Name   string
+	Params int
+	VarArg bool
+	Func   func(i *Interpreter, args []any) (any, error)
+}
+
+func (n *NativeFunction) Call(i *Interpreter, args []any) (any, error) {
+	return n.Func(i, args)
+}
+
+func (n *NativeFunction) Arity() (int, bool) {
+	return n.Params, n.VarArg
+}
+
+func (n *NativeFunction) Bind(this any) Callable {
+	return n
+}
+
+func (n *NativeFunction) String() string {
+	return fmt.Sprintf("<native fn %v>", n.Name)
+}
+
+func nativeMethod(name scanner.Token, params int, f func(i *Interpreter, args []any) (any, error)) Callable {
+	return &NativeFunction{
+		Name:   name.Lexeme,
+		Params: params,
+		Func:   f,
+	}
+}
+
+func intFunction(i *Interpreter, args []any) (any, error) {
+	switch value := args[0].(type) {
+	case int64:
+		return value, nil
+	case float64:
+		if integer, ok := floatToInt(math.Trunc(value)); ok {
+			return integer, nil
+		}
+	case string:
+		if integer, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return integer, nil
+		}
+	}
+	return nil, util.ReportErrorOnToken(i.callSite, "cannot convert '%v' to int", args[0])
+}
+
+func floatFunction(i *Interpreter, args []any) (any, error) {
+	switch value := args[0].(type) {
+	case int64:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case string:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number, nil
+		}
+	}
+	return nil, util.ReportErrorOnToken(i.callSite, "cannot convert '%v' to float", args[0])
+}
+
+// floatToInt converts value to an int if it has no fractional part
+// and fits into the int range
+func floatToInt(value float64) (int64, bool) {
+	if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(value), true
+}
+
+// docFunction returns the `///` doc comment of a function or a class, nil if it has none
+func docFunction(i *Interpreter, args []any) (any, error) {
+	var doc string
+	switch value := args[0].(type) {
+	case *CallableObject:
+		doc = value.Declaration.Doc
+	case *Class:
+		doc = value.Doc
+	case *Trait:
+		doc = value.Doc
+	case Callable:
+	default:
+		return nil, util.ReportErrorOnToken(i.callSite, "only functions, classes and traits have doc comments, got '%v'", stringify(args[0]))
+	}
+
+	if doc == "" {
+		return nil, nil
+	}
+	return doc, nil
+}
@@ -24,8 +24,32 @@ func (c *CallableObject) Bind(this any) Callable {
 func (c *CallableObject) Call(i *Interpreter, args []any) (any, error) {
 	env := NewSubEnvironment(c.Closure)
 
-	for i, arg := range args {
-		env.Define(c.Declaration.Params[i].Lexeme, arg)
+	params := c.Declaration.Params
+	for idx, param := range params {
+		if c.Declaration.Variadic && idx == len(params)-1 {
+			rest := make([]any, 0)
+			if idx < len(args) {
+				rest = append(rest, args[idx:]...)
+			}
+			env.Define(param.Lexeme, &List{Elements: rest})
+			break
+		}
+
+		if idx < len(args) && args[idx] != missingArgument {
+			env.Define(param.Lexeme, args[idx])
+			continue
+		}
+
+		// defaults are evaluated on every call, after the parameters before them
+		var value any
+		if def := c.Declaration.Defaults[idx]; def != nil {
+			var err error
+			value, err = i.evalIn(def, env)
+			if err != nil {
+				return nil, err
+			}
+		}
+		env.Define(param.Lexeme, value)
 	}
 	err := i.executeBlock(c.Declaration.Body, env)
 
@@ -40,8 +64,10 @@ func (c *CallableObject) Call(i *Interpreter, args []any) (any, error) {
 	return nil, nil
 }
 
+// Arity returns the number of required parameters and whether the function takes a rest parameter
 func (c *CallableObject) Arity() (int, bool) {
-	return len(c.Declaration.Params), false
+	_, required := positionalParams(c.Declaration)
+	return required, c.Declaration.Variadic
 }
 
 func (c *CallableObject) String() string {
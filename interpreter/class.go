@@ -2,7 +2,10 @@ package interpreter
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/Valeron93/crafting-interpreters/ast"
 	"github.com/Valeron93/crafting-interpreters/scanner"
 	"github.com/Valeron93/crafting-interpreters/util"
 )
@@ -12,21 +15,52 @@ type Class struct {
 	Methods     map[string]ClassMethod
 	Constructor Callable
 	Superclass  *Class
+	Doc         string
+	// Fields are the declared fields every instance gets before `init` runs,
+	// their initializers are evaluated in Closure with `this` bound to the instance
+	Fields       []*ast.VarStmt
+	Closure      *Environment
+	StaticFields map[string]any
+
+	// Declaration and PrivateMethods are set for classes declared by scripts,
+	// private members are only accessible from the methods of the declaring class
+	Declaration    *ast.ClassDeclStmt
+	PrivateMethods map[string]Callable
+
+	// Abstract are the abstract methods the class doesn't implement,
+	// classes with abstract methods can't be instantiated
+	Abstract map[string]*ast.FuncDeclStmt
 }
 
 type ClassMethod struct {
 	Callable Callable
 	Static   bool
+	// Getter and Setter are set instead of Callable for computed properties
+	Getter Callable
+	Setter Callable
+}
+
+// IsAccessor reports whether the method is a computed property
+func (m ClassMethod) IsAccessor() bool {
+	return m.Getter != nil || m.Setter != nil
 }
 
 func (c *Class) Call(i *Interpreter, args []any) (any, error) {
+	if len(c.Abstract) > 0 {
+		return nil, util.ReportErrorOnToken(i.callSite, "cannot instantiate abstract class '%v'", c.Name)
+	}
+
 	instance := &ClassInstance{
 		Class:  c,
 		Fields: make(map[string]any),
 	}
 
-	if c.Constructor != nil {
-		_, err := c.Constructor.Bind(instance).Call(i, args)
+	if err := c.initFields(i, instance); err != nil {
+		return nil, err
+	}
+
+	if constructor := c.FindConstructor(); constructor != nil {
+		_, err := constructor.Bind(instance).Call(i, args)
 		if err != nil {
 			return nil, err
 		}
@@ -35,9 +69,41 @@ func (c *Class) Call(i *Interpreter, args []any) (any, error) {
 	return instance, nil
 }
 
+// initFields sets the declared fields of instance, the fields of the base class are set first
+func (c *Class) initFields(i *Interpreter, instance *ClassInstance) error {
+	if c.Superclass != nil {
+		if err := c.Superclass.initFields(i, instance); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Fields) == 0 {
+		return nil
+	}
+
+	env := NewSubEnvironment(c.Closure)
+	env.Define("this", instance)
+	for _, field := range c.Fields {
+		var value any
+		if field.Init != nil {
+			var err error
+			value, err = i.evalIn(field.Init, env)
+			if err != nil {
+				return err
+			}
+		}
+		if field.Name.Type == scanner.PrivateIdent {
+			instance.privateSlots(c)[field.Name.Lexeme] = value
+		} else {
+			instance.Fields[field.Name.Lexeme] = value
+		}
+	}
+	return nil
+}
+
 func (c *Class) Arity() (int, bool) {
-	if c.Constructor != nil {
-		return c.Constructor.Arity()
+	if constructor := c.FindConstructor(); constructor != nil {
+		return constructor.Arity()
 	}
 	return 0, false
 }
@@ -50,24 +116,148 @@ func (c *Class) Bind(this any) Callable {
 	return c
 }
 
+// FindConstructor looks up `init`, classes without one inherit the one of their superclass
+func (c *Class) FindConstructor() Callable {
+	for current := c; current != nil; current = current.Superclass {
+		if current.Constructor != nil {
+			return current.Constructor
+		}
+	}
+	return nil
+}
+
 func (c *Class) FindMethod(name string) (Callable, bool) {
-	if method, ok := c.Methods[name]; ok {
-		return method.Callable, !method.Static
+	if name == "init" {
+		constructor := c.FindConstructor()
+		return constructor, constructor != nil
 	}
-	if c.Superclass != nil {
-		return c.Superclass.FindMethod(name)
+
+	if method, ok := c.findMember(name); ok && !method.IsAccessor() {
+		return method.Callable, !method.Static
 	}
 
 	return nil, false
 }
 
-func (c *Class) Set(name scanner.Token, value any) error {
-	return util.ReportErrorOnToken(name, "assigning to class object is not supported")
+// FindAccessor looks up the getter and setter of a computed property
+func (c *Class) FindAccessor(name string) (ClassMethod, bool) {
+	if method, ok := c.findMember(name); ok && method.IsAccessor() {
+		return method, true
+	}
+
+	return ClassMethod{}, false
+}
+
+// findMember looks up a method or an accessor, members of subclasses hide the ones of their superclasses
+func (c *Class) findMember(name string) (ClassMethod, bool) {
+	for current := c; current != nil; current = current.Superclass {
+		if method, ok := current.Methods[name]; ok {
+			return method, true
+		}
+	}
+
+	return ClassMethod{}, false
+}
+
+// IsSubclassOf reports whether c is class or inherits from it
+func (c *Class) IsSubclassOf(class *Class) bool {
+	for current := c; current != nil; current = current.Superclass {
+		if current == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Set assigns a static field, in the class which declares it
+func (c *Class) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	for current := c; current != nil; current = current.Superclass {
+		if _, ok := current.StaticFields[name.Lexeme]; ok {
+			current.StaticFields[name.Lexeme] = value
+			return nil
+		}
+	}
+	return util.ReportErrorOnToken(name, "class '%v' has no static field '%v'", c.Name, name.Lexeme)
+}
+
+// Get looks up a static field or a static method, the ones of the superclasses are inherited
+func (c *Class) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	for current := c; current != nil; current = current.Superclass {
+		if value, ok := current.StaticFields[name.Lexeme]; ok {
+			return value, nil
+		}
+		if method, ok := current.Methods[name.Lexeme]; ok && !method.IsAccessor() {
+			return method.Callable, nil
+		}
+	}
+	return nil, util.ReportErrorOnToken(name, "class '%v' has no static field or method '%v'", c.Name, name.Lexeme)
+}
+
+// hasStatic reports whether the class has a static field or method with the given name
+func (c *Class) hasStatic(name string) bool {
+	for current := c; current != nil; current = current.Superclass {
+		if _, ok := current.StaticFields[name]; ok {
+			return true
+		}
+		if method, ok := current.Methods[name]; ok && !method.IsAccessor() {
+			return true
+		}
+	}
+	return false
 }
 
-func (c *Class) Get(name scanner.Token) (any, error) {
-	if method, ok := c.Methods[name.Lexeme]; ok {
-		return method.Callable, nil
+// abstractMethods returns the abstract methods of a class being declared.
+// A class implements the abstract methods of its superclass by methods accepting the same number of arguments,
+// unless the class declares abstract methods itself, then the unimplemented ones are inherited.
+// See sameArguments for the rule
+func abstractMethods(stmt *ast.ClassDeclStmt, superclass *Class, methods map[string]ClassMethod) (map[string]*ast.FuncDeclStmt, error) {
+	abstract := make(map[string]*ast.FuncDeclStmt)
+	for _, method := range stmt.Methods {
+		if _, ok := methods[method.Func.Name.Lexeme]; method.Abstract && !ok {
+			abstract[method.Func.Name.Lexeme] = method.Func
+		}
+	}
+	isAbstract := len(abstract) > 0
+	if superclass == nil {
+		return abstract, nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(superclass.Abstract)) {
+		fn := superclass.Abstract[name]
+		if _, ok := abstract[name]; ok {
+			continue
+		}
+
+		method, ok := methods[name]
+		if !ok || method.Static || method.IsAccessor() {
+			if isAbstract {
+				abstract[name] = fn
+				continue
+			}
+			return nil, util.ReportErrorOnToken(stmt.Name, "class '%v' must implement abstract method '%v' of class '%v'", stmt.Name.Lexeme, name, superclass.Name)
+		}
+
+		decl, ok := declaration(method.Callable)
+		if !ok || sameArguments(decl, fn) {
+			continue
+		}
+		if decl.Variadic != fn.Variadic {
+			if decl.Variadic {
+				return nil, util.ReportErrorOnToken(decl.Name, "method '%v' has a rest parameter, but the abstract method of class '%v' doesn't", name, superclass.Name)
+			}
+			return nil, util.ReportErrorOnToken(decl.Name, "method '%v' has no rest parameter, but the abstract method of class '%v' has one", name, superclass.Name)
+		}
+		return nil, util.ReportErrorOnToken(decl.Name, "method '%v' accepts %v arguments, but the abstract method of class '%v' accepts %v",
+			name, acceptedArguments(decl), superclass.Name, acceptedArguments(fn))
 	}
-	return nil, util.ReportErrorOnToken(name, "class '%v' has no static method '%v'", c.Name, name.Lexeme)
+	return abstract, nil
+}
+
+// sameArguments reports whether the functions declared by a and b accept the same numbers of arguments:
+// both have a rest parameter or neither has, and they have as many parameters without and with default values.
+// The parameters following the required ones aren't compared if there is a rest parameter
+func sameArguments(a *ast.FuncDeclStmt, b *ast.FuncDeclStmt) bool {
+	positionalA, requiredA := positionalParams(a)
+	positionalB, requiredB := positionalParams(b)
+	return a.Variadic == b.Variadic && requiredA == requiredB && (a.Variadic || positionalA == positionalB)
 }
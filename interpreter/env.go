@@ -8,6 +8,9 @@ import (
 type Environment struct {
 	variables map[string]any
 	enclosing *Environment
+	// globals is the outermost environment of the module this environment
+	// belongs to, so functions imported from other modules see their own globals
+	globals *Environment
 }
 
 func NewEnvironment() *Environment {
@@ -16,10 +19,17 @@ func NewEnvironment() *Environment {
 }
 
 func NewSubEnvironment(enclosing *Environment) *Environment {
-	return &Environment{
+	env := &Environment{
 		variables: make(map[string]any),
 		enclosing: enclosing,
 	}
+
+	if enclosing != nil {
+		env.globals = enclosing.globals
+	} else {
+		env.globals = env
+	}
+	return env
 }
 
 func (e *Environment) Get(name scanner.Token) (any, error) {
@@ -0,0 +1,80 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// ThrownError carries a value raised by `throw` up to the nearest catch block
+type ThrownError struct {
+	Value   any
+	Keyword scanner.Token
+}
+
+func (t *ThrownError) Error() string {
+	return fmt.Sprintf("%v:%v: uncaught exception: %v", t.Keyword.Line, t.Keyword.Column, t.Value)
+}
+
+// ErrorConstructor is the `init` of the builtin Error class,
+// `Error(message)` stores the message in the `message` field
+type ErrorConstructor struct {
+	this *ClassInstance
+}
+
+func (e *ErrorConstructor) Call(i *Interpreter, args []any) (any, error) {
+	e.this.Fields["message"] = args[0]
+	e.this.Fields["line"] = nil
+	e.this.Fields["column"] = nil
+	return nil, nil
+}
+
+func (e *ErrorConstructor) Arity() (int, bool) {
+	return 1, false
+}
+
+func (e *ErrorConstructor) Bind(this any) Callable {
+	return &ErrorConstructor{
+		this: this.(*ClassInstance),
+	}
+}
+
+func newErrorClass() *Class {
+	return &Class{
+		Name:        "Error",
+		Methods:     make(map[string]ClassMethod),
+		Constructor: &ErrorConstructor{},
+	}
+}
+
+// caught converts err into the value bound by a catch block.
+// Values thrown by the script are passed as is, runtime errors become
+// instances of the Error class. Errors used for control flow can't be caught.
+func (i *Interpreter) caught(err error) (any, bool) {
+	switch err := err.(type) {
+	case *ThrownError:
+		return err.Value, true
+	case *FunctionReturn, *LoopBreak, *LoopContinue:
+		return nil, false
+	}
+
+	instance := &ClassInstance{
+		Class: i.errorClass,
+		Fields: map[string]any{
+			"message": err.Error(),
+			"line":    nil,
+			"column":  nil,
+		},
+	}
+
+	var located *util.Error
+	if errors.As(err, &located) {
+		instance.Fields["message"] = located.Message
+		instance.Fields["line"] = int64(located.Line)
+		instance.Fields["column"] = int64(located.Column)
+	}
+
+	return instance, true
+}
@@ -2,6 +2,8 @@ package interpreter
 
 import (
 	"errors"
+	"math"
+	"strings"
 
 	"github.com/Valeron93/crafting-interpreters/ast"
 	"github.com/Valeron93/crafting-interpreters/scanner"
@@ -18,24 +20,109 @@ func (i *Interpreter) VisitAssignExpr(expr *ast.AssignExpr) (any, error) {
 		return nil, err
 	}
 
-	distance, ok := i.locals[expr]
-	if ok {
-		err = i.env.AssignAt(distance, expr.Name, value)
+	err = i.assignVar(expr.Name, expr, value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// compoundOperators maps compound assignment operators to the binary operators they apply
+var compoundOperators = map[scanner.TokenType]scanner.TokenType{
+	scanner.PlusEqual:    scanner.Plus,
+	scanner.MinusEqual:   scanner.Minus,
+	scanner.StarEqual:    scanner.Star,
+	scanner.SlashEqual:   scanner.Slash,
+	scanner.PercentEqual: scanner.Percent,
+	scanner.PlusPlus:     scanner.Plus,
+	scanner.MinusMinus:   scanner.Minus,
+}
+
+// VisitCompoundAssignExpr evaluates the object and the key of the target only once,
+// so `obj.next()[i] += 1` calls next() a single time
+func (i *Interpreter) VisitCompoundAssignExpr(expr *ast.CompoundAssignExpr) (any, error) {
+	operator := expr.Operator
+	operator.Type = compoundOperators[expr.Operator.Type]
+
+	apply := func(current any) (any, error) {
+		value, err := i.Eval(expr.Value)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		err = i.globals.Assign(expr.Name, value)
+		return i.binaryOperator(operator, current, value)
+	}
+
+	var object, key, current, result any
+	var err error
+
+	switch target := expr.Target.(type) {
+	case *ast.VarExpr:
+		current, err = i.lookUpVar(target.Name, target)
+		if err != nil {
+			return nil, err
+		}
+		result, err = apply(current)
+		if err != nil {
+			return nil, err
+		}
+		err = i.assignVar(target.Name, target, result)
+
+	case *ast.GetExpr:
+		object, err = i.Eval(target.Object)
+		if err != nil {
+			return nil, err
+		}
+		var obj Object
+		var ok bool
+		obj, ok, err = i.members(target, object, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, util.ReportErrorOnToken(target.Name, "only class instances have properties")
+		}
+		current, err = obj.Get(i, target.Name)
+		if err != nil {
+			return nil, err
+		}
+		result, err = apply(current)
+		if err != nil {
+			return nil, err
+		}
+		err = obj.Set(i, target.Name, result)
+
+	case *ast.GetKeyExpr:
+		object, err = i.Eval(target.Object)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := object.(IndexableObject)
+		if !ok {
+			return nil, util.ReportErrorOnToken(target.Bracket, "indexing is not supported on this object")
+		}
+		key, err = i.Eval(target.Key)
+		if err != nil {
+			return nil, err
+		}
+		current, err = obj.GetKeyValue(i, target.Bracket, key)
 		if err != nil {
 			return nil, err
 		}
+		result, err = apply(current)
+		if err != nil {
+			return nil, err
+		}
+		err = obj.SetKeyValue(i, target.Bracket, key, result)
 	}
 
-	err = i.env.Assign(expr.Name, value)
 	if err != nil {
 		return nil, err
 	}
-	return value, nil
+
+	if expr.Postfix {
+		return current, nil
+	}
+	return result, nil
 }
 
 func (i *Interpreter) VisitBinaryExpr(expr *ast.BinaryExpr) (any, error) {
@@ -48,31 +135,48 @@ func (i *Interpreter) VisitBinaryExpr(expr *ast.BinaryExpr) (any, error) {
 		return nil, err
 	}
 
-	switch expr.Operator.Type {
+	return i.binaryOperator(expr.Operator, left, right)
+}
+
+func (i *Interpreter) binaryOperator(operator scanner.Token, left any, right any) (any, error) {
+	if result, ok, err := i.overloadedOperator(operator, left, right); ok || err != nil {
+		return result, err
+	}
+
+	switch operator.Type {
 	case scanner.EqualEqual:
-		return left == right, nil
+		return isEqual(left, right), nil
 	case scanner.BangEqual:
-		return left != right, nil
+		return !isEqual(left, right), nil
 	}
-	lhsFloat, lhsIsFloat := left.(float64)
-	rhsFloat, rhsIsFloat := right.(float64)
+
+	lhsInt, lhsIsInt := left.(int64)
+	rhsInt, rhsIsInt := right.(int64)
+
+	lhsFloat, lhsIsNumber := toFloat(left)
+	rhsFloat, rhsIsNumber := toFloat(right)
 
 	lhsString, lhsIsString := left.(string)
 	rhsString, rhsIsString := right.(string)
 
-	if lhsIsFloat && rhsIsFloat {
+	if lhsIsInt && rhsIsInt {
+		return intOperator(operator, lhsInt, rhsInt)
+	} else if isBitwiseOperator(operator.Type) {
+		return nil, util.ReportErrorOnToken(operator, "bitwise operator '%v' expects integer operands, got '%v' and '%v'", operator.Lexeme, stringify(left), stringify(right))
+	} else if lhsIsNumber && rhsIsNumber {
 
-		switch expr.Operator.Type {
-		case scanner.Plus, scanner.Minus, scanner.Star, scanner.Slash:
-			return floatOperator(expr.Operator, lhsFloat, rhsFloat), nil
+		switch operator.Type {
+		case scanner.Plus, scanner.Minus, scanner.Star, scanner.Slash, scanner.TildeSlash,
+			scanner.Percent, scanner.StarStar:
+			return floatOperator(operator, lhsFloat, rhsFloat), nil
 
 		case scanner.Greater, scanner.GreaterEqual, scanner.Less, scanner.LessEqual:
-			return floatLogicOperator(expr.Operator, lhsFloat, rhsFloat), nil
+			return floatLogicOperator(operator, lhsFloat, rhsFloat), nil
 		}
-	} else if (lhsIsString && rhsIsString) && expr.Operator.Type == scanner.Plus {
+	} else if (lhsIsString && rhsIsString) && operator.Type == scanner.Plus {
 		return lhsString + rhsString, nil
 	} else {
-		return nil, util.ReportErrorOnToken(expr.Operator, "operands '%v' and '%v' are not compatible with binary operator '%v'", left, right, expr.Operator.Lexeme)
+		return nil, util.ReportErrorOnToken(operator, "operands '%v' and '%v' are not compatible with binary operator '%v'", left, right, operator.Lexeme)
 	}
 
 	return nil, errors.ErrUnsupported
@@ -88,7 +192,25 @@ func (i *Interpreter) VisitUnaryExpr(expr *ast.UnaryExpr) (any, error) {
 	case scanner.Bang:
 		return !(i.isTrue(right)), nil
 	case scanner.Minus:
-		return -(right.(float64)), nil
+		if result, ok, err := i.callOperatorMethod(expr.Operator, right, "__neg"); ok || err != nil {
+			return result, err
+		}
+
+		switch number := right.(type) {
+		case int64:
+			if number == math.MinInt64 {
+				return nil, util.ReportErrorOnToken(expr.Operator, "integer overflow in unary operator '-'")
+			}
+			return -number, nil
+		case float64:
+			return -number, nil
+		}
+		return nil, util.ReportErrorOnToken(expr.Operator, "operand '%v' is not compatible with unary operator '-'", right)
+	case scanner.Tilde:
+		if number, ok := right.(int64); ok {
+			return ^number, nil
+		}
+		return nil, util.ReportErrorOnToken(expr.Operator, "bitwise operator '~' expects an integer operand, got '%v'", stringify(right))
 	}
 	return nil, nil
 }
@@ -125,28 +247,44 @@ func (i *Interpreter) VisitCallExpr(expr *ast.CallExpr) (any, error) {
 		return nil, err
 	}
 
-	args := make([]any, 0)
-
-	for _, arg := range expr.Args {
-		value, err := i.Eval(arg)
-		if err != nil {
-			return nil, err
-		}
-		args = append(args, value)
+	args, keywords, err := i.evalArguments(expr)
+	if err != nil {
+		return nil, err
 	}
 
 	f, ok := callee.(Callable)
 	if !ok {
 		return nil, util.ReportErrorOnToken(expr.Paren, "'%#v' is not callable", callee)
 	}
-	arity, varArg := f.Arity()
-	if !varArg && len(args) != arity {
-		return nil, util.ReportErrorOnToken(expr.Paren, "function '%v' expects %v arguments, got: %v", callee, arity, len(args))
+	if decl, ok := declaration(f); ok {
+		args, err = i.bindArguments(expr.Paren, callee, decl, args, keywords)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(keywords) > 0 {
+			return nil, util.ReportErrorOnToken(keywords[0].Name, "function '%v' doesn't accept keyword arguments", callee)
+		}
+
+		arity, varArg := f.Arity()
+		if !varArg && len(args) != arity {
+			return nil, util.ReportErrorOnToken(expr.Paren, "function '%v' expects %v arguments, got: %v", callee, arity, len(args))
+		}
 	}
 
+	prevCallSite := i.callSite
+	i.callSite = expr.Paren
+	defer func() {
+		i.callSite = prevCallSite
+	}()
+
 	return f.Call(i, args)
 }
 
+func (i *Interpreter) VisitSpreadExpr(expr *ast.SpreadExpr) (any, error) {
+	return nil, util.ReportErrorOnToken(expr.Ellipsis, "'...' is only allowed in call arguments and list literals")
+}
+
 func (i *Interpreter) VisitLambdaExpr(expr *ast.LambdaExpr) (any, error) {
 	return &CallableObject{
 		Declaration: &ast.FuncDeclStmt{
@@ -154,8 +292,10 @@ func (i *Interpreter) VisitLambdaExpr(expr *ast.LambdaExpr) (any, error) {
 				Type:   scanner.Ident,
 				Lexeme: "lambda",
 			},
-			Params: expr.Params,
-			Body:   expr.Body,
+			Params:   expr.Params,
+			Defaults: expr.Defaults,
+			Variadic: expr.Variadic,
+			Body:     expr.Body,
 		},
 		Closure: i.env,
 	}, nil
@@ -167,8 +307,12 @@ func (i *Interpreter) VisitGetExpr(expr *ast.GetExpr) (any, error) {
 		return nil, err
 	}
 
-	if object, ok := object.(Object); ok {
-		return object.Get(expr.Name)
+	obj, ok, err := i.members(expr, object, expr.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return obj.Get(i, expr.Name)
 	}
 
 	return nil, util.ReportErrorOnToken(expr.Name, "only classes and class instances have properties")
@@ -180,13 +324,17 @@ func (i *Interpreter) VisitSetExpr(expr *ast.SetExpr) (any, error) {
 		return nil, err
 	}
 
-	if obj, ok := object.(Object); ok {
+	obj, ok, err := i.members(expr, object, expr.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
 		value, err := i.Eval(expr.Value)
 		if err != nil {
 			return nil, err
 		}
 
-		err = obj.Set(expr.Name, value)
+		err = obj.Set(i, expr.Name, value)
 		if err != nil {
 			return nil, err
 		}
@@ -282,3 +430,83 @@ func (i *Interpreter) VisitSuperExpr(expr *ast.SuperExpr) (any, error) {
 	return method.Bind(instance), nil
 
 }
+
+func (i *Interpreter) VisitListExpr(expr *ast.ListExpr) (any, error) {
+	elements := make([]any, 0, len(expr.Elements))
+
+	for _, element := range expr.Elements {
+		if spread, ok := element.(*ast.SpreadExpr); ok {
+			iterable, err := i.Eval(spread.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			err = i.iterate(spread.Ellipsis, iterable, func(value any) (bool, error) {
+				elements = append(elements, value)
+				return true, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, err := i.Eval(element)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+	}
+
+	return &List{
+		Elements: elements,
+	}, nil
+}
+
+func (i *Interpreter) VisitMapExpr(expr *ast.MapExpr) (any, error) {
+	m := NewMap()
+
+	for idx := range expr.Keys {
+		key, err := i.Eval(expr.Keys[idx])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := i.Eval(expr.Values[idx])
+		if err != nil {
+			return nil, err
+		}
+
+		if err = m.Put(expr.Brace, key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (i *Interpreter) VisitInterpolationExpr(expr *ast.InterpolationExpr) (any, error) {
+	var b strings.Builder
+
+	for _, part := range expr.Parts {
+		value, err := i.Eval(part)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(stringify(value))
+	}
+
+	return b.String(), nil
+}
+
+func (i *Interpreter) VisitConditionalExpr(expr *ast.ConditionalExpr) (any, error) {
+	condition, err := i.Eval(expr.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.isTrue(condition) {
+		return i.Eval(expr.Then)
+	}
+	return i.Eval(expr.Else)
+}
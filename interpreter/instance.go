@@ -3,6 +3,7 @@ package interpreter
 import (
 	"fmt"
 
+	"github.com/Valeron93/crafting-interpreters/ast"
 	"github.com/Valeron93/crafting-interpreters/scanner"
 	"github.com/Valeron93/crafting-interpreters/util"
 )
@@ -10,14 +11,72 @@ import (
 type ClassInstance struct {
 	Class  *Class
 	Fields map[string]any
+	// private has the private fields of every class in the hierarchy separately,
+	// so private fields of a class never collide with ones of its subclasses
+	private map[*Class]map[string]any
 }
 
-func (c *ClassInstance) Set(name scanner.Token, value any) error {
+func (c *ClassInstance) privateSlots(class *Class) map[string]any {
+	if c.private == nil {
+		c.private = make(map[*Class]map[string]any)
+	}
+	if c.private[class] == nil {
+		c.private[class] = make(map[string]any)
+	}
+	return c.private[class]
+}
+
+// declaringClass finds the class of the instance, or its superclass, declared by decl
+func (c *ClassInstance) declaringClass(decl *ast.ClassDeclStmt) (*Class, bool) {
+	for class := c.Class; class != nil; class = class.Superclass {
+		if class.Declaration == decl {
+			return class, true
+		}
+	}
+	return nil, false
+}
+
+// GetPrivate reads a private field or method declared by class
+func (c *ClassInstance) GetPrivate(class *Class, name scanner.Token) (any, error) {
+	if value, ok := c.privateSlots(class)[name.Lexeme]; ok {
+		return value, nil
+	}
+	if method, ok := class.PrivateMethods[name.Lexeme]; ok {
+		return method.Bind(c), nil
+	}
+	return nil, util.ReportErrorOnToken(name, "undefined private field '%v'", name.Lexeme)
+}
+
+// SetPrivate assigns a private field declared by class
+func (c *ClassInstance) SetPrivate(class *Class, name scanner.Token, value any) error {
+	if _, ok := class.PrivateMethods[name.Lexeme]; ok {
+		return util.ReportErrorOnToken(name, "cannot assign to private method '%v'", name.Lexeme)
+	}
+	c.privateSlots(class)[name.Lexeme] = value
+	return nil
+}
+
+func (c *ClassInstance) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	if accessor, ok := c.Class.FindAccessor(name.Lexeme); ok {
+		if accessor.Setter == nil {
+			return util.ReportErrorOnToken(name, "property '%v' has no setter", name.Lexeme)
+		}
+		_, err := accessor.Setter.Bind(c).Call(interpreter, []any{value})
+		return err
+	}
+
 	c.Fields[name.Lexeme] = value
 	return nil
 }
 
-func (c *ClassInstance) Get(name scanner.Token) (any, error) {
+func (c *ClassInstance) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	if accessor, ok := c.Class.FindAccessor(name.Lexeme); ok {
+		if accessor.Getter == nil {
+			return nil, util.ReportErrorOnToken(name, "property '%v' has no getter", name.Lexeme)
+		}
+		return accessor.Getter.Bind(c).Call(interpreter, nil)
+	}
+
 	if field, ok := c.Fields[name.Lexeme]; ok {
 		return field, nil
 	}
@@ -37,7 +96,7 @@ func (c *ClassInstance) SetKeyValue(interpreter *Interpreter, bracket scanner.To
 
 	if method, ok := c.Class.FindMethod("__set"); ok {
 
-		_, err := method.Bind(c).Call(interpreter, []any{key, value})
+		_, err := interpreter.callSpecial(bracket, "__set", method.Bind(c), key, value)
 
 		if err != nil {
 			return err
@@ -52,7 +111,7 @@ func (c *ClassInstance) SetKeyValue(interpreter *Interpreter, bracket scanner.To
 func (c *ClassInstance) GetKeyValue(interpreter *Interpreter, bracket scanner.Token, key any) (any, error) {
 
 	if method, ok := c.Class.FindMethod("__get"); ok {
-		value, err := method.Bind(c).Call(interpreter, []any{key})
+		value, err := interpreter.callSpecial(bracket, "__get", method.Bind(c), key)
 		if err != nil {
 			return nil, err
 		}
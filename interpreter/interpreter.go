@@ -1,14 +1,34 @@
 package interpreter
 
 import (
+	"math"
+
 	"github.com/Valeron93/crafting-interpreters/ast"
 	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
 )
 
 type Interpreter struct {
-	env     *Environment
-	globals *Environment
-	locals  map[ast.Expr]int
+	env        *Environment
+	globals    *Environment
+	locals     map[ast.Expr]int
+	errorClass *Class
+	// builtins are the globals defined by the interpreter which the script hasn't redeclared
+	builtins map[string]bool
+	// privates maps accesses of private members to the class declaring them
+	privates map[ast.Expr]*ast.ClassDeclStmt
+	// checkedTraits are the class declarations whose traits the resolver has checked
+	checkedTraits map[*ast.ClassDeclStmt]bool
+
+	// callSite is the closing paren of the call being executed,
+	// native functions report their errors on it
+	callSite scanner.Token
+
+	modules *moduleLoader
+	// module is the module being executed, nil for the main script
+	module *Module
+	// dir is the directory imports are relative to
+	dir string
 }
 
 func (i *Interpreter) GlobalExists(lexeme string) bool {
@@ -17,31 +37,72 @@ func (i *Interpreter) GlobalExists(lexeme string) bool {
 	return ok
 }
 
+// IsBuiltin reports whether a global is defined by the interpreter and not redeclared by the script
+func (i *Interpreter) IsBuiltin(lexeme string) bool {
+	return i.builtins[lexeme]
+}
+
 func (i *Interpreter) DefineGlobal(name string, value any) {
 	i.globals.variables[name] = value
+	delete(i.builtins, name)
 }
 
 type FunctionReturn struct {
 	Value any
 }
 
+// LoopBreak and LoopContinue unwind the interpreter
+// up to the enclosing loop, the same way FunctionReturn does
+type LoopBreak struct {
+}
+
+type LoopContinue struct {
+}
+
 func New() Interpreter {
 	env := NewEnvironment()
 	i := Interpreter{
-		env:     env,
-		globals: env,
-		locals:  make(map[ast.Expr]int),
+		env:           env,
+		globals:       env,
+		locals:        make(map[ast.Expr]int),
+		errorClass:    newErrorClass(),
+		privates:      make(map[ast.Expr]*ast.ClassDeclStmt),
+		checkedTraits: make(map[*ast.ClassDeclStmt]bool),
+		modules:       newModuleLoader(),
+		dir:           ".",
 	}
-	i.env.Define("clock", &ClockFunction{})
-	i.env.Define("print", &PrintFunction{})
+	i.defineBuiltins()
 
 	return i
 }
 
+func (i *Interpreter) defineBuiltins() {
+	i.globals.Define("clock", &ClockFunction{})
+	i.globals.Define("print", &PrintFunction{})
+	i.globals.Define("Error", i.errorClass)
+	i.globals.Define("StopIteration", StopIteration)
+	i.globals.Define("int", &NativeFunction{Name: "int", Params: 1, Func: intFunction})
+	i.globals.Define("float", &NativeFunction{Name: "float", Params: 1, Func: floatFunction})
+	i.globals.Define("doc", &NativeFunction{Name: "doc", Params: 1, Func: docFunction})
+
+	i.builtins = make(map[string]bool)
+	for name := range i.globals.variables {
+		i.builtins[name] = true
+	}
+}
+
 func (f *FunctionReturn) Error() string {
 	return "FunctionReturn"
 }
 
+func (b *LoopBreak) Error() string {
+	return "LoopBreak"
+}
+
+func (c *LoopContinue) Error() string {
+	return "LoopContinue"
+}
+
 func floatOperator(operator scanner.Token, lhs float64, rhs float64) float64 {
 
 	switch operator.Type {
@@ -56,6 +117,15 @@ func floatOperator(operator scanner.Token, lhs float64, rhs float64) float64 {
 
 	case scanner.Star:
 		return lhs * rhs
+
+	case scanner.TildeSlash:
+		return math.Trunc(lhs / rhs)
+
+	case scanner.Percent:
+		return math.Mod(lhs, rhs)
+
+	case scanner.StarStar:
+		return math.Pow(lhs, rhs)
 	}
 
 	panic("unreachable: floatOperator")
@@ -79,10 +149,167 @@ func floatLogicOperator(operator scanner.Token, lhs float64, rhs float64) bool {
 	panic("unreachable: floatLogicOperator")
 }
 
+// intOperator applies operator to integers, reporting overflows instead of wrapping around.
+// Division with '/' always produces a float, '~/' is the integer division.
+func intOperator(operator scanner.Token, lhs int64, rhs int64) (any, error) {
+	var result int64
+	overflow := false
+
+	switch operator.Type {
+	case scanner.Plus:
+		result = lhs + rhs
+		overflow = (rhs > 0 && result < lhs) || (rhs < 0 && result > lhs)
+
+	case scanner.Minus:
+		result = lhs - rhs
+		overflow = (rhs > 0 && result > lhs) || (rhs < 0 && result < lhs)
+
+	case scanner.Star:
+		result, overflow = multiply(lhs, rhs)
+
+	case scanner.Slash:
+		return float64(lhs) / float64(rhs), nil
+
+	case scanner.TildeSlash:
+		if rhs == 0 {
+			return nil, util.ReportErrorOnToken(operator, "integer division by zero")
+		}
+		overflow = lhs == math.MinInt64 && rhs == -1
+		result = lhs / rhs
+
+	case scanner.Percent:
+		if rhs == 0 {
+			return nil, util.ReportErrorOnToken(operator, "integer modulo by zero")
+		}
+		// MinInt64 % -1 is 0, no overflow is possible
+		result = lhs % rhs
+
+	case scanner.StarStar:
+		// negative powers can't be represented as integers
+		if rhs < 0 {
+			return math.Pow(float64(lhs), float64(rhs)), nil
+		}
+		result, overflow = power(lhs, rhs)
+
+	case scanner.Ampersand:
+		result = lhs & rhs
+
+	case scanner.Pipe:
+		result = lhs | rhs
+
+	case scanner.Caret:
+		result = lhs ^ rhs
+
+	case scanner.LessLess, scanner.GreaterGreater:
+		if rhs < 0 || rhs > 63 {
+			return nil, util.ReportErrorOnToken(operator, "shift count %v is out of range [0, 63]", rhs)
+		}
+		if operator.Type == scanner.LessLess {
+			result = lhs << rhs
+		} else {
+			result = lhs >> rhs
+		}
+
+	case scanner.Greater:
+		return lhs > rhs, nil
+
+	case scanner.GreaterEqual:
+		return lhs >= rhs, nil
+
+	case scanner.Less:
+		return lhs < rhs, nil
+
+	case scanner.LessEqual:
+		return lhs <= rhs, nil
+
+	default:
+		return nil, util.ReportErrorOnToken(operator, "operands '%v' and '%v' are not compatible with binary operator '%v'", lhs, rhs, operator.Lexeme)
+	}
+
+	if overflow {
+		return nil, util.ReportErrorOnToken(operator, "integer overflow in '%v %v %v'", lhs, operator.Lexeme, rhs)
+	}
+	return result, nil
+}
+
+func multiply(lhs int64, rhs int64) (int64, bool) {
+	result := lhs * rhs
+	overflow := lhs != 0 && (result/lhs != rhs || (lhs == -1 && rhs == math.MinInt64))
+	return result, overflow
+}
+
+// power computes base**exponent by squaring, exponent must not be negative
+func power(base int64, exponent int64) (int64, bool) {
+	result := int64(1)
+	overflow := false
+
+	for exponent > 0 {
+		var mulOverflow bool
+		if exponent&1 == 1 {
+			result, mulOverflow = multiply(result, base)
+			overflow = overflow || mulOverflow
+		}
+		exponent >>= 1
+		if exponent > 0 {
+			base, mulOverflow = multiply(base, base)
+			overflow = overflow || mulOverflow
+		}
+	}
+	return result, overflow
+}
+
+func isBitwiseOperator(typ scanner.TokenType) bool {
+	switch typ {
+	case scanner.Ampersand, scanner.Pipe, scanner.Caret, scanner.LessLess, scanner.GreaterGreater:
+		return true
+	}
+	return false
+}
+
+// toFloat converts any number to a float, so that ints are promoted
+// when they are used together with floats
+func toFloat(value any) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
+
+func isEqual(lhs any, rhs any) bool {
+	lhsInt, lhsIsInt := lhs.(int64)
+	rhsInt, rhsIsInt := rhs.(int64)
+	if lhsIsInt && rhsIsInt {
+		return lhsInt == rhsInt
+	}
+
+	lhsFloat, lhsIsNumber := toFloat(lhs)
+	rhsFloat, rhsIsNumber := toFloat(rhs)
+	if lhsIsNumber && rhsIsNumber {
+		return lhsFloat == rhsFloat
+	}
+
+	return lhs == rhs
+}
+
 func (i *Interpreter) Eval(expr ast.Expr) (any, error) {
 	return expr.Accept(i)
 }
 
+// evalIn evaluates expr with env as the current environment
+func (i *Interpreter) evalIn(expr ast.Expr, env *Environment) (any, error) {
+	prevEnv := i.env
+
+	defer func() {
+		i.env = prevEnv
+	}()
+
+	i.env = env
+	return i.Eval(expr)
+}
+
 func (i *Interpreter) isTrue(obj any) bool {
 	if obj == nil {
 		return false
@@ -130,11 +357,30 @@ func (i *Interpreter) Resolve(expr ast.Expr, depth int) {
 	i.locals[expr] = depth
 }
 
+// ResolveTraits records that the resolver has checked the traits of the class declared by stmt
+func (i *Interpreter) ResolveTraits(stmt *ast.ClassDeclStmt) {
+	i.checkedTraits[stmt] = true
+}
+
+// ResolvePrivate records the class declaring the private member accessed by expr
+func (i *Interpreter) ResolvePrivate(expr ast.Expr, class *ast.ClassDeclStmt) {
+	i.privates[expr] = class
+}
+
+func (i *Interpreter) assignVar(name scanner.Token, expr ast.Expr, value any) error {
+	distance, ok := i.locals[expr]
+	if ok {
+		return i.env.AssignAt(distance, name, value)
+	} else {
+		return i.env.globals.Assign(name, value)
+	}
+}
+
 func (i *Interpreter) lookUpVar(name scanner.Token, expr ast.Expr) (any, error) {
 	distance, ok := i.locals[expr]
 	if ok {
 		return i.env.GetAt(distance, name)
 	} else {
-		return i.globals.Get(name)
+		return i.env.globals.Get(name)
 	}
 }
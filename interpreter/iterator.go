@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"slices"
+
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+type Sentinel struct {
+	Name string
+}
+
+func (s *Sentinel) String() string {
+	return s.Name
+}
+
+// StopIteration is returned by `__next()` of an iterator when it is exhausted
+var StopIteration = &Sentinel{Name: "StopIteration"}
+
+// iterate calls yield with every value of iterable, until it returns false.
+// Lists, maps (their keys) and strings (their characters) are iterable,
+// as well as instances of classes with an `__iter()` method.
+func (i *Interpreter) iterate(token scanner.Token, iterable any, yield func(value any) (bool, error)) error {
+	switch iterable := iterable.(type) {
+	case *List:
+		// the list may change while iterating, so the length is checked every time
+		for idx := 0; idx < len(iterable.Elements); idx++ {
+			if ok, err := yield(iterable.Elements[idx]); !ok || err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *Map:
+		for _, key := range slices.Clone(iterable.keys) {
+			if ok, err := yield(key); !ok || err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case string:
+		for _, r := range iterable {
+			if ok, err := yield(string(r)); !ok || err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case *ClassInstance:
+		return i.iterateInstance(token, iterable, yield)
+	}
+
+	return util.ReportErrorOnToken(token, "'%v' is not iterable", stringify(iterable))
+}
+
+func (i *Interpreter) iterateInstance(token scanner.Token, instance *ClassInstance, yield func(value any) (bool, error)) error {
+	iter, ok := instance.Class.FindMethod("__iter")
+	if !ok {
+		return util.ReportErrorOnToken(token, "class '%v' does not have 'fn __iter()' method", instance.Class.Name)
+	}
+
+	iterator, err := i.callSpecial(token, "__iter", iter.Bind(instance))
+	if err != nil {
+		return err
+	}
+
+	object, ok := iterator.(Object)
+	if !ok {
+		return util.ReportErrorOnToken(token, "__iter() returned '%v', which is not an iterator", stringify(iterator))
+	}
+
+	nextToken := token
+	nextToken.Lexeme = "__next"
+	method, err := object.Get(i, nextToken)
+	if err != nil {
+		return err
+	}
+
+	next, ok := method.(Callable)
+	if !ok {
+		return util.ReportErrorOnToken(token, "__next of '%v' is not callable", stringify(iterator))
+	}
+
+	for {
+		value, err := i.callSpecial(token, "__next", next)
+		if err != nil {
+			return err
+		}
+		if value == StopIteration {
+			return nil
+		}
+		if ok, err := yield(value); !ok || err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+type List struct {
+	Elements []any
+}
+
+func (l *List) String() string {
+	var b strings.Builder
+	b.WriteRune('[')
+	for idx, element := range l.Elements {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(inspect(element))
+	}
+	b.WriteRune(']')
+	return b.String()
+}
+
+func (l *List) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	return util.ReportErrorOnToken(name, "cannot assign properties on a list")
+}
+
+func (l *List) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	switch name.Lexeme {
+	case "len":
+		return nativeMethod(name, 0, func(i *Interpreter, args []any) (any, error) {
+			return int64(len(l.Elements)), nil
+		}), nil
+
+	case "push":
+		return nativeMethod(name, 1, func(i *Interpreter, args []any) (any, error) {
+			l.Elements = append(l.Elements, args[0])
+			return nil, nil
+		}), nil
+
+	case "pop":
+		return nativeMethod(name, 0, func(i *Interpreter, args []any) (any, error) {
+			if len(l.Elements) == 0 {
+				return nil, util.ReportErrorOnToken(name, "cannot pop from an empty list")
+			}
+			last := l.Elements[len(l.Elements)-1]
+			l.Elements = l.Elements[:len(l.Elements)-1]
+			return last, nil
+		}), nil
+
+	case "insert":
+		return nativeMethod(name, 2, func(i *Interpreter, args []any) (any, error) {
+			idx, err := l.index(name, args[0], len(l.Elements))
+			if err != nil {
+				return nil, err
+			}
+			l.Elements = append(l.Elements, nil)
+			copy(l.Elements[idx+1:], l.Elements[idx:])
+			l.Elements[idx] = args[1]
+			return nil, nil
+		}), nil
+
+	case "slice":
+		return nativeMethod(name, 2, func(i *Interpreter, args []any) (any, error) {
+			start, err := l.index(name, args[0], len(l.Elements))
+			if err != nil {
+				return nil, err
+			}
+			end, err := l.index(name, args[1], len(l.Elements))
+			if err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, util.ReportErrorOnToken(name, "slice start %v is greater than end %v", start, end)
+			}
+			elements := make([]any, end-start)
+			copy(elements, l.Elements[start:end])
+			return &List{Elements: elements}, nil
+		}), nil
+	}
+
+	return nil, util.ReportErrorOnToken(name, "list has no method '%v'", name.Lexeme)
+}
+
+func (l *List) GetKeyValue(interpreter *Interpreter, bracket scanner.Token, key any) (any, error) {
+	idx, err := l.index(bracket, key, len(l.Elements)-1)
+	if err != nil {
+		return nil, err
+	}
+	return l.Elements[idx], nil
+}
+
+func (l *List) SetKeyValue(interpreter *Interpreter, bracket scanner.Token, key any, value any) error {
+	idx, err := l.index(bracket, key, len(l.Elements)-1)
+	if err != nil {
+		return err
+	}
+	l.Elements[idx] = value
+	return nil
+}
+
+// index converts key to a list index in range [0, max]
+func (l *List) index(token scanner.Token, key any, max int) (int, error) {
+	idx, ok := toIndex(key)
+	if !ok {
+		return 0, util.ReportErrorOnToken(token, "list index must be an integer, got %v", inspect(key))
+	}
+	if idx < 0 || idx > max {
+		return 0, util.ReportErrorOnToken(token, "list index %v is out of range for list of length %v", idx, len(l.Elements))
+	}
+	return idx, nil
+}
+
+func toIndex(value any) (int, bool) {
+	number, ok := value.(int64)
+	return int(number), ok
+}
+
+// inspect formats values nested inside collections, quoting strings
+// so that `["1"]` and `[1]` print differently
+func inspect(value any) string {
+	if str, ok := value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return stringify(value)
+}
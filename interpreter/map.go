@@ -0,0 +1,152 @@
+package interpreter
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// Map keeps its keys in insertion order, so that printing and
+// iterating over `keys()` and `values()` are deterministic
+type Map struct {
+	entries map[any]any
+	keys    []any
+}
+
+func NewMap() *Map {
+	return &Map{
+		entries: make(map[any]any),
+		keys:    make([]any, 0),
+	}
+}
+
+func (m *Map) Put(token scanner.Token, key any, value any) error {
+	key, err := mapKey(token, key)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.entries[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.entries[key] = value
+	return nil
+}
+
+func (m *Map) Lookup(token scanner.Token, key any) (any, bool, error) {
+	key, err := mapKey(token, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, ok := m.entries[key]
+	return value, ok, nil
+}
+
+func (m *Map) Delete(token scanner.Token, key any) (bool, error) {
+	key, err := mapKey(token, key)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := m.entries[key]; !ok {
+		return false, nil
+	}
+
+	delete(m.entries, key)
+	idx := slices.Index(m.keys, key)
+	m.keys = slices.Delete(m.keys, idx, idx+1)
+	return true, nil
+}
+
+func (m *Map) String() string {
+	var b strings.Builder
+	b.WriteRune('{')
+	for idx, key := range m.keys {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(inspect(key))
+		b.WriteString(": ")
+		b.WriteString(inspect(m.entries[key]))
+	}
+	b.WriteRune('}')
+	return b.String()
+}
+
+func (m *Map) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	return util.ReportErrorOnToken(name, "cannot assign properties on a map, use m[key] = value instead")
+}
+
+func (m *Map) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	switch name.Lexeme {
+	case "len":
+		return nativeMethod(name, 0, func(i *Interpreter, args []any) (any, error) {
+			return int64(len(m.keys)), nil
+		}), nil
+
+	case "keys":
+		return nativeMethod(name, 0, func(i *Interpreter, args []any) (any, error) {
+			return &List{Elements: slices.Clone(m.keys)}, nil
+		}), nil
+
+	case "values":
+		return nativeMethod(name, 0, func(i *Interpreter, args []any) (any, error) {
+			values := make([]any, 0, len(m.keys))
+			for _, key := range m.keys {
+				values = append(values, m.entries[key])
+			}
+			return &List{Elements: values}, nil
+		}), nil
+
+	case "has":
+		return nativeMethod(name, 1, func(i *Interpreter, args []any) (any, error) {
+			_, ok, err := m.Lookup(name, args[0])
+			return ok, err
+		}), nil
+
+	case "delete":
+		return nativeMethod(name, 1, func(i *Interpreter, args []any) (any, error) {
+			return m.Delete(name, args[0])
+		}), nil
+	}
+
+	return nil, util.ReportErrorOnToken(name, "map has no method '%v'", name.Lexeme)
+}
+
+func (m *Map) GetKeyValue(interpreter *Interpreter, bracket scanner.Token, key any) (any, error) {
+	value, ok, err := m.Lookup(bracket, key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, util.ReportErrorOnToken(bracket, "key %v not found in map", inspect(key))
+	}
+	return value, nil
+}
+
+func (m *Map) SetKeyValue(interpreter *Interpreter, bracket scanner.Token, key any, value any) error {
+	return m.Put(bracket, key, value)
+}
+
+// mapKey checks that key is hashable and normalizes it,
+// so that keys which compare equal in the language share an entry
+func mapKey(token scanner.Token, key any) (any, error) {
+	switch key := key.(type) {
+	case nil, string, bool, int64:
+		return key, nil
+	case float64:
+		if math.IsNaN(key) {
+			return nil, util.ReportErrorOnToken(token, "NaN cannot be used as a map key")
+		}
+		// 1 == 1.0, so they must be the same key
+		if integer, ok := floatToInt(key); ok {
+			return integer, nil
+		}
+		return key, nil
+	}
+	return nil, util.ReportErrorOnToken(token, "map keys must be strings, numbers, booleans or null, got '%v'", key)
+}
@@ -0,0 +1,288 @@
+package interpreter
+
+import (
+	"slices"
+
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+func (i *Interpreter) VisitMatchStmt(stmt *ast.MatchStmt) (any, error) {
+	value, err := i.Eval(stmt.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arm := range stmt.Arms {
+		env := NewSubEnvironment(i.env)
+
+		matched, err := i.matchPattern(arm.Pattern, value, env)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard, err := i.evalIn(arm.Guard, env)
+			if err != nil {
+				return nil, err
+			}
+			if !i.isTrue(guard) {
+				continue
+			}
+		}
+
+		return nil, i.executeBlock([]ast.Stmt{arm.Body}, env)
+	}
+
+	return nil, util.ReportErrorOnToken(stmt.Keyword, "no match arm matched value '%v'", stringify(value))
+}
+
+// matchPattern tests value against pattern, defining the bound names in env
+func (i *Interpreter) matchPattern(pattern ast.Pattern, value any, env *Environment) (bool, error) {
+	switch pattern := pattern.(type) {
+	case *ast.LiteralPattern:
+		return isEqual(pattern.Value, value), nil
+
+	case *ast.WildcardPattern:
+		return true, nil
+
+	case *ast.BindingPattern:
+		return true, env.Define(pattern.Name.Lexeme, value)
+
+	case *ast.ClassPattern:
+		// the class is resolved in the scope of the arm, like defaults of the pattern
+		object, err := i.evalIn(pattern.Class, env)
+		if err != nil {
+			return false, err
+		}
+
+		class, ok := object.(*Class)
+		if !ok {
+			return false, util.ReportErrorOnToken(pattern.Class.Name, "'%v' is not a class", pattern.Class.Name.Lexeme)
+		}
+
+		instance, ok := value.(*ClassInstance)
+		if !ok || !instance.Class.IsSubclassOf(class) {
+			return false, nil
+		}
+
+		for _, field := range pattern.Fields {
+			fieldValue, err := instance.Get(i, field)
+			if err != nil {
+				return false, err
+			}
+			if err = env.Define(field.Lexeme, fieldValue); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case *ast.ListPattern:
+		list, ok := value.(*List)
+		if !ok || (len(list.Elements) > len(pattern.Elements) && pattern.Rest == nil) {
+			return false, nil
+		}
+
+		// the list is copied, a default value may change it
+		elements := slices.Clone(list.Elements)
+		for idx, element := range pattern.Elements {
+			var matched bool
+			var err error
+			if idx < len(elements) {
+				matched, err = i.matchPattern(element, elements[idx], env)
+			} else {
+				matched, err = i.matchMissing(element, env)
+			}
+			if !matched || err != nil {
+				return false, err
+			}
+		}
+
+		if pattern.Rest != nil {
+			rest := make([]any, 0)
+			if len(elements) > len(pattern.Elements) {
+				rest = append(rest, elements[len(pattern.Elements):]...)
+			}
+			return true, env.Define(pattern.Rest.Lexeme, &List{Elements: rest})
+		}
+		return true, nil
+
+	case *ast.ObjectPattern:
+		object, ok := value.(Object)
+		if !ok {
+			return false, nil
+		}
+
+		for idx, key := range pattern.Keys {
+			field, found, err := i.patternField(object, key)
+			if err != nil {
+				return false, err
+			}
+
+			// properties with a default value may be missing, otherwise the pattern doesn't match
+			var matched bool
+			if found {
+				matched, err = i.matchPattern(pattern.Values[idx], field, env)
+			} else {
+				matched, err = i.matchMissing(pattern.Values[idx], env)
+			}
+			if !matched || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case *ast.DefaultPattern:
+		return i.matchPattern(pattern.Pattern, value, env)
+	}
+
+	panic("unreachable: matchPattern")
+}
+
+// matchMissing matches pattern of a missing list element or key,
+// only patterns with a default value match it
+func (i *Interpreter) matchMissing(pattern ast.Pattern, env *Environment) (bool, error) {
+	def, ok := pattern.(*ast.DefaultPattern)
+	if !ok {
+		return false, nil
+	}
+
+	value, err := i.evalIn(def.Default, env)
+	if err != nil {
+		return false, err
+	}
+	return i.matchPattern(def.Pattern, value, env)
+}
+
+// patternField looks up key of an object pattern in the keys of a map or the properties of an object.
+// found is false if the object has no such key or property, errors getting it, e.g. of a getter, are returned
+func (i *Interpreter) patternField(object Object, key scanner.Token) (field any, found bool, err error) {
+	switch object := object.(type) {
+	case *Map:
+		return object.Lookup(key, key.Lexeme)
+
+	case *ClassInstance:
+		_, accessor := object.Class.FindAccessor(key.Lexeme)
+		_, field := object.Fields[key.Lexeme]
+		_, method := object.Class.FindMethod(key.Lexeme)
+		if !accessor && !field && !method {
+			return nil, false, nil
+		}
+
+	case *Class:
+		if !object.hasStatic(key.Lexeme) {
+			return nil, false, nil
+		}
+
+	case *Module:
+		if !object.exports[key.Lexeme] {
+			return nil, false, nil
+		}
+
+	default:
+		// the properties of the other objects are their native methods, getting them only fails if they are missing
+		field, err := object.Get(i, key)
+		return field, err == nil, nil
+	}
+
+	field, err = object.Get(i, key)
+	return field, err == nil, err
+}
+
+func (i *Interpreter) VisitDestructureStmt(stmt *ast.DestructureStmt) (any, error) {
+	value, err := i.Eval(stmt.Init)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := i.matchPattern(stmt.Pattern, value, i.env)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, util.ReportErrorOnToken(stmt.Keyword, "value '%v' doesn't match the destructuring pattern", inspect(value))
+	}
+	return nil, nil
+}
+
+func (i *Interpreter) VisitDestructureExpr(expr *ast.DestructureExpr) (any, error) {
+	value, err := i.Eval(expr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, i.assignTarget(expr.Target, value)
+}
+
+// assignTarget assigns value to a target of a destructuring assignment
+func (i *Interpreter) assignTarget(target ast.Expr, value any) error {
+	switch target := target.(type) {
+	case *ast.VarExpr:
+		return i.assignVar(target.Name, target, value)
+
+	case *ast.GetExpr:
+		object, err := i.Eval(target.Object)
+		if err != nil {
+			return err
+		}
+		obj, ok, err := i.members(target, object, target.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return util.ReportErrorOnToken(target.Name, "only class instances have properties")
+		}
+		return obj.Set(i, target.Name, value)
+
+	case *ast.GetKeyExpr:
+		object, err := i.Eval(target.Object)
+		if err != nil {
+			return err
+		}
+		obj, ok := object.(IndexableObject)
+		if !ok {
+			return util.ReportErrorOnToken(target.Bracket, "indexing is not supported on this object")
+		}
+		key, err := i.Eval(target.Key)
+		if err != nil {
+			return err
+		}
+		return obj.SetKeyValue(i, target.Bracket, key, value)
+
+	case *ast.ListExpr:
+		list, ok := value.(*List)
+		if !ok {
+			return util.ReportErrorOnToken(target.Bracket, "cannot destructure '%v', expected a list", inspect(value))
+		}
+
+		elements := slices.Clone(list.Elements)
+		targets := target.Elements
+		var rest *ast.SpreadExpr
+		if len(targets) > 0 {
+			if spread, ok := targets[len(targets)-1].(*ast.SpreadExpr); ok {
+				rest = spread
+				targets = targets[:len(targets)-1]
+			}
+		}
+
+		if len(elements) < len(targets) || (len(elements) > len(targets) && rest == nil) {
+			return util.ReportErrorOnToken(target.Bracket, "cannot destructure a list of %v elements into %v targets", len(elements), len(targets))
+		}
+
+		for idx, element := range targets {
+			if err := i.assignTarget(element, elements[idx]); err != nil {
+				return err
+			}
+		}
+		if rest != nil {
+			return i.assignTarget(rest.Value, &List{Elements: elements[len(targets):]})
+		}
+		return nil
+	}
+
+	panic("unreachable: assignTarget")
+}
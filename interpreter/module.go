@@ -0,0 +1,198 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/parser"
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// ModuleResolver resolves variables of an imported module before it is executed.
+// The resolver package depends on the interpreter, so it is injected
+// with Interpreter.SetModuleResolver instead of being called directly.
+type ModuleResolver func(i *Interpreter, stmts []ast.Stmt) []error
+
+type Module struct {
+	Name    string
+	Path    string
+	globals *Environment
+	exports map[string]bool
+}
+
+func (m *Module) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	if !m.exports[name.Lexeme] {
+		return nil, util.ReportErrorOnToken(name, "module '%v' does not export '%v'", m.Name, name.Lexeme)
+	}
+	return m.globals.variables[name.Lexeme], nil
+}
+
+func (m *Module) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	return util.ReportErrorOnToken(name, "cannot assign to members of module '%v'", m.Name)
+}
+
+func (m *Module) String() string {
+	return fmt.Sprintf("<module %v>", m.Name)
+}
+
+// moduleLoader is shared between the interpreters of all modules of a program
+type moduleLoader struct {
+	resolve ModuleResolver
+	cache   map[string]*Module
+	// loading is the chain of modules currently being imported, used to detect cycles
+	loading []string
+}
+
+func newModuleLoader() *moduleLoader {
+	return &moduleLoader{
+		cache:   make(map[string]*Module),
+		loading: make([]string, 0),
+	}
+}
+
+func (i *Interpreter) SetModuleResolver(resolve ModuleResolver) {
+	i.modules.resolve = resolve
+}
+
+// SetEntryFile makes imports relative to the directory of the script at path
+func (i *Interpreter) SetEntryFile(path string) error {
+	canonical, err := canonicalPath(path)
+	if err != nil {
+		return err
+	}
+	i.dir = filepath.Dir(canonical)
+	i.modules.loading = append(i.modules.loading, canonical)
+	return nil
+}
+
+func (i *Interpreter) importModule(stmt *ast.ImportStmt) (*Module, error) {
+	path := stmt.Path.Literal.(string)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(i.dir, path)
+	}
+
+	canonical, err := canonicalPath(path)
+	if err != nil {
+		return nil, util.ReportErrorOnToken(stmt.Path, "failed to import '%v': %v", stmt.Path.Literal, err)
+	}
+
+	if module, ok := i.modules.cache[canonical]; ok {
+		return module, nil
+	}
+
+	if idx := slices.Index(i.modules.loading, canonical); idx >= 0 {
+		cycle := make([]string, 0)
+		for _, loading := range i.modules.loading[idx:] {
+			cycle = append(cycle, displayPath(loading))
+		}
+		cycle = append(cycle, displayPath(canonical))
+		return nil, util.ReportErrorOnToken(stmt.Path, "import cycle: %v", strings.Join(cycle, " -> "))
+	}
+
+	i.modules.loading = append(i.modules.loading, canonical)
+	defer func() {
+		i.modules.loading = i.modules.loading[:len(i.modules.loading)-1]
+	}()
+
+	module, err := i.loadModule(canonical)
+	if err != nil {
+		return nil, util.ReportErrorOnToken(stmt.Path, "failed to import '%v': %v", stmt.Path.Literal, err)
+	}
+
+	i.modules.cache[canonical] = module
+	return module, nil
+}
+
+// loadModule scans, parses, resolves and executes the module at path
+// in its own global environment
+func (i *Interpreter) loadModule(path string) (*Module, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := displayPath(path)
+	s := scanner.NewScanner(string(bytes))
+	tokens, errs := s.ScanTokens()
+	if len(errs) > 0 {
+		return nil, moduleErrors(name, errs)
+	}
+
+	p := parser.NewParser(tokens)
+	stmts, errs := p.Parse()
+	if len(errs) > 0 {
+		return nil, moduleErrors(name, errs)
+	}
+
+	env := NewEnvironment()
+	module := &Module{
+		Name:    strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		Path:    path,
+		globals: env,
+		exports: make(map[string]bool),
+	}
+
+	sub := &Interpreter{
+		env:           env,
+		globals:       env,
+		locals:        i.locals,
+		errorClass:    i.errorClass,
+		privates:      i.privates,
+		checkedTraits: i.checkedTraits,
+		modules:       i.modules,
+		module:        module,
+		dir:           filepath.Dir(path),
+	}
+	sub.defineBuiltins()
+
+	if i.modules.resolve != nil {
+		errs = i.modules.resolve(sub, stmts)
+		if len(errs) > 0 {
+			return nil, moduleErrors(name, errs)
+		}
+	}
+
+	if err := sub.Interpret(stmts); err != nil {
+		return nil, moduleErrors(name, []error{err})
+	}
+
+	return module, nil
+}
+
+func moduleErrors(name string, errs []error) error {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, fmt.Sprintf("%v:%v", name, err))
+	}
+	return fmt.Errorf("%v", strings.Join(messages, "\n"))
+}
+
+func canonicalPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+	return abs, nil
+}
+
+// displayPath shortens path for error messages when it is inside the working directory
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -3,8 +3,8 @@ package interpreter
 import "github.com/Valeron93/crafting-interpreters/scanner"
 
 type Object interface {
-	Set(name scanner.Token, value any) error
-	Get(name scanner.Token) (any, error)
+	Set(interpreter *Interpreter, name scanner.Token, value any) error
+	Get(interpreter *Interpreter, name scanner.Token) (any, error)
 }
 
 type IndexableObject interface {
@@ -0,0 +1,78 @@
+package interpreter
+
+import "github.com/Valeron93/crafting-interpreters/scanner"
+
+// operatorMethods maps binary operators to the methods overloading them
+var operatorMethods = map[scanner.TokenType]string{
+	scanner.Plus:         "__add",
+	scanner.Minus:        "__sub",
+	scanner.Star:         "__mul",
+	scanner.Slash:        "__div",
+	scanner.EqualEqual:   "__eq",
+	scanner.BangEqual:    "__eq",
+	scanner.Less:         "__lt",
+	scanner.LessEqual:    "__le",
+	scanner.Greater:      "__le",
+	scanner.GreaterEqual: "__lt",
+}
+
+// swappedComparisons maps `>` and `>=` to the methods of the right operand evaluating them
+var swappedComparisons = map[scanner.TokenType]string{
+	scanner.Greater:      "__lt",
+	scanner.GreaterEqual: "__le",
+}
+
+// overloadedOperator calls the method overloading operator, if one of the operands has it.
+// Arithmetic operators try `left.__add(right)` first, then the reflected `right.__radd(left)`.
+// `a > b` and `a >= b` are evaluated as `!(a <= b)` and `!(a < b)` first, then as `b < a` and `b <= a`.
+func (i *Interpreter) overloadedOperator(operator scanner.Token, left any, right any) (any, bool, error) {
+	name, ok := operatorMethods[operator.Type]
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch operator.Type {
+	case scanner.EqualEqual, scanner.BangEqual:
+		result, ok, err := i.callOperatorMethod(operator, left, name, right)
+		if !ok && err == nil {
+			result, ok, err = i.callOperatorMethod(operator, right, name, left)
+		}
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		return i.isTrue(result) == (operator.Type == scanner.EqualEqual), true, nil
+
+	case scanner.Less, scanner.LessEqual:
+		return i.callOperatorMethod(operator, left, name, right)
+
+	case scanner.Greater, scanner.GreaterEqual:
+		result, ok, err := i.callOperatorMethod(operator, left, name, right)
+		if ok || err != nil {
+			return !i.isTrue(result), ok, err
+		}
+		return i.callOperatorMethod(operator, right, swappedComparisons[operator.Type], left)
+	}
+
+	result, ok, err := i.callOperatorMethod(operator, left, name, right)
+	if ok || err != nil {
+		return result, ok, err
+	}
+	return i.callOperatorMethod(operator, right, "__r"+name[2:], left)
+}
+
+// callOperatorMethod calls method name of object with args, if object is an instance having it.
+// Errors, e.g. a method taking a wrong number of arguments, are reported on the operator
+func (i *Interpreter) callOperatorMethod(operator scanner.Token, object any, name string, args ...any) (any, bool, error) {
+	instance, ok := object.(*ClassInstance)
+	if !ok {
+		return nil, false, nil
+	}
+
+	method, ok := instance.Class.FindMethod(name)
+	if !ok {
+		return nil, false, nil
+	}
+
+	result, err := i.callSpecial(operator, name, method.Bind(instance), args...)
+	return result, true, err
+}
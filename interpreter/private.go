@@ -0,0 +1,38 @@
+package interpreter
+
+import (
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/scanner"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// privateMembers is a view of the private members one class declares on an instance
+type privateMembers struct {
+	instance *ClassInstance
+	class    *Class
+}
+
+func (p privateMembers) Get(interpreter *Interpreter, name scanner.Token) (any, error) {
+	return p.instance.GetPrivate(p.class, name)
+}
+
+func (p privateMembers) Set(interpreter *Interpreter, name scanner.Token, value any) error {
+	return p.instance.SetPrivate(p.class, name, value)
+}
+
+// members returns the object whose properties are accessed by the get or set expression access.
+// ok is false if object has no properties at all
+func (i *Interpreter) members(access ast.Expr, object any, name scanner.Token) (Object, bool, error) {
+	decl, private := i.privates[access]
+	if !private {
+		obj, ok := object.(Object)
+		return obj, ok, nil
+	}
+
+	if instance, ok := object.(*ClassInstance); ok {
+		if class, ok := instance.declaringClass(decl); ok {
+			return privateMembers{instance: instance, class: class}, true, nil
+		}
+	}
+	return nil, false, util.ReportErrorOnToken(name, "private member '%v' is only accessible on instances of class '%v'", name.Lexeme, decl.Name.Lexeme)
+}
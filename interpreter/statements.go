@@ -2,6 +2,7 @@ package interpreter
 
 import (
 	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/scanner"
 	"github.com/Valeron93/crafting-interpreters/util"
 )
 
@@ -63,7 +64,19 @@ func (i *Interpreter) VisitWhileStmt(stmt *ast.WhileStmt) (any, error) {
 		}
 		err = i.execute(stmt.Body)
 		if err != nil {
-			return nil, err
+			if _, ok := err.(*LoopBreak); ok {
+				break
+			}
+			if _, ok := err.(*LoopContinue); !ok {
+				return nil, err
+			}
+		}
+
+		if stmt.Increment != nil {
+			_, err = i.Eval(stmt.Increment)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -113,6 +126,20 @@ func (i *Interpreter) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 			return nil, util.ReportErrorOnToken(stmt.Superclass.Name, "'%v' is not a class", stmt.Superclass.Name.Lexeme)
 		}
 	}
+
+	traits := make([]*Trait, 0, len(stmt.Traits))
+	for _, name := range stmt.Traits {
+		value, err := i.Eval(name)
+		if err != nil {
+			return nil, err
+		}
+
+		trait, ok := value.(*Trait)
+		if !ok {
+			return nil, util.ReportErrorOnToken(name.Name, "'%v' is not a trait", name.Name.Lexeme)
+		}
+		traits = append(traits, trait)
+	}
 	i.env.Define(stmt.Name.Lexeme, nil)
 
 	if stmt.Superclass != nil {
@@ -122,7 +149,35 @@ func (i *Interpreter) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 
 	methods := make(map[string]ClassMethod)
 	var init Callable
+	privateMethods := make(map[string]Callable)
 	for _, method := range stmt.Methods {
+		if method.Abstract {
+			continue
+		}
+
+		if method.Func.Name.Type == scanner.PrivateIdent {
+			privateMethods[method.Func.Name.Lexeme] = &CallableObject{
+				Declaration: method.Func,
+				Closure:     i.env,
+			}
+			continue
+		}
+
+		if method.Getter || method.Setter {
+			accessor := methods[method.Func.Name.Lexeme]
+			callable := &CallableObject{
+				Declaration: method.Func,
+				Closure:     i.env,
+			}
+			if method.Getter {
+				accessor.Getter = callable
+			} else {
+				accessor.Setter = callable
+			}
+			methods[method.Func.Name.Lexeme] = accessor
+			continue
+		}
+
 		if method.Func.Name.Lexeme == "init" {
 			init = &CallableObject{
 				Declaration: method.Func,
@@ -138,18 +193,51 @@ func (i *Interpreter) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 			Static: method.Static,
 		}
 	}
+
+	if err := mixTraits(stmt, traits, superclass, methods, !i.checkedTraits[stmt]); err != nil {
+		return nil, err
+	}
+
+	abstract, err := abstractMethods(stmt, superclass, methods)
+	if err != nil {
+		return nil, err
+	}
+
 	class := &Class{
 		Name:        stmt.Name.Lexeme,
 		Methods:     methods,
 		Constructor: init,
 		Superclass:  superclass,
+		Doc:         stmt.Doc,
+
+		Fields:       stmt.Fields,
+		Closure:      i.env,
+		StaticFields: make(map[string]any),
+
+		Declaration:    stmt,
+		PrivateMethods: privateMethods,
+		Abstract:       abstract,
 	}
 
+	classEnv := i.env
 	if superclass != nil {
 		i.env = i.env.enclosing
 	}
 
 	i.env.Assign(stmt.Name, class)
+
+	// static fields are initialized in order, after the class is defined, so they can refer to it
+	for _, field := range stmt.StaticFields {
+		var value any
+		if field.Init != nil {
+			var err error
+			value, err = i.evalIn(field.Init, classEnv)
+			if err != nil {
+				return nil, err
+			}
+		}
+		class.StaticFields[field.Name.Lexeme] = value
+	}
 	return nil, nil
 }
 
@@ -161,3 +249,137 @@ func (i *Interpreter) VisitMethodDeclStmt(stmt *ast.MethodDeclStmt) (any, error)
 	// TODO: maybe, there is a better approach?
 	return nil, nil
 }
+
+func (i *Interpreter) VisitBreakStmt(stmt *ast.BreakStmt) (any, error) {
+	return nil, &LoopBreak{}
+}
+
+func (i *Interpreter) VisitContinueStmt(stmt *ast.ContinueStmt) (any, error) {
+	return nil, &LoopContinue{}
+}
+
+func (i *Interpreter) VisitThrowStmt(stmt *ast.ThrowStmt) (any, error) {
+	value, err := i.Eval(stmt.Value)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &ThrownError{
+		Value:   value,
+		Keyword: stmt.Keyword,
+	}
+}
+
+func (i *Interpreter) VisitTryStmt(stmt *ast.TryStmt) (any, error) {
+	err := i.executeBlock(stmt.Body, NewSubEnvironment(i.env))
+
+	if err != nil && stmt.CatchName != nil {
+		if value, ok := i.caught(err); ok {
+			env := NewSubEnvironment(i.env)
+			env.Define(stmt.CatchName.Lexeme, value)
+			err = i.executeBlock(stmt.Catch, env)
+		}
+	}
+
+	if stmt.Finally != nil {
+		// an error in the finally block replaces the pending one
+		finallyErr := i.executeBlock(stmt.Finally, NewSubEnvironment(i.env))
+		if finallyErr != nil {
+			return nil, finallyErr
+		}
+	}
+
+	return nil, err
+}
+
+func (i *Interpreter) VisitImportStmt(stmt *ast.ImportStmt) (any, error) {
+	module, err := i.importModule(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Alias != nil {
+		return nil, i.env.Define(stmt.Alias.Lexeme, module)
+	}
+
+	for _, name := range stmt.Names {
+		value, err := module.Get(i, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = i.env.Define(name.Lexeme, value); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (i *Interpreter) VisitExportStmt(stmt *ast.ExportStmt) (any, error) {
+	if err := i.execute(stmt.Decl); err != nil {
+		return nil, err
+	}
+
+	// the main script is not imported by anything, so its exports are not recorded
+	if i.module == nil {
+		return nil, nil
+	}
+
+	switch decl := stmt.Decl.(type) {
+	case *ast.FuncDeclStmt:
+		i.module.exports[decl.Name.Lexeme] = true
+	case *ast.ClassDeclStmt:
+		i.module.exports[decl.Name.Lexeme] = true
+	case *ast.TraitDeclStmt:
+		i.module.exports[decl.Name.Lexeme] = true
+	case *ast.VarStmt:
+		i.module.exports[decl.Name.Lexeme] = true
+	case *ast.DestructureStmt:
+		for _, name := range ast.Bindings(decl.Pattern) {
+			i.module.exports[name.Lexeme] = true
+		}
+	}
+	return nil, nil
+}
+
+func (i *Interpreter) VisitForInStmt(stmt *ast.ForInStmt) (any, error) {
+	iterable, err := i.Eval(stmt.Iterable)
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.iterate(stmt.Keyword, iterable, func(value any) (bool, error) {
+		env := NewSubEnvironment(i.env)
+		env.Define(stmt.Name.Lexeme, value)
+
+		err := i.executeBlock([]ast.Stmt{stmt.Body}, env)
+		if err != nil {
+			if _, ok := err.(*LoopBreak); ok {
+				return false, nil
+			}
+			if _, ok := err.(*LoopContinue); !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+
+	return nil, err
+}
+
+func (i *Interpreter) VisitTraitDeclStmt(stmt *ast.TraitDeclStmt) (any, error) {
+	methods := make(map[string]Callable)
+	for _, method := range stmt.Methods {
+		methods[method.Name.Lexeme] = &CallableObject{
+			Declaration: method,
+			Closure:     i.env,
+		}
+	}
+
+	i.env.Define(stmt.Name.Lexeme, &Trait{
+		Name:     stmt.Name.Lexeme,
+		Methods:  methods,
+		Required: stmt.Required,
+		Doc:      stmt.Doc,
+	})
+	return nil, nil
+}
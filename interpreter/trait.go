@@ -0,0 +1,86 @@
+package interpreter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// Trait is a set of methods that classes declared `with` the trait get as their own
+type Trait struct {
+	Name    string
+	Methods map[string]Callable
+	// Required are the signatures of methods a class must implement to use the trait
+	Required []*ast.FuncDeclStmt
+	Doc      string
+}
+
+func (t *Trait) String() string {
+	return fmt.Sprintf("<trait %v>", t.Name)
+}
+
+// mixTraits adds the methods of traits to methods of the class declared by stmt.
+// Members of the class override the methods of its traits.
+// If check is set, a method defined by more than one trait has to be overridden, and the methods
+// required by the traits have to be implemented by the class, by one of its traits or by its superclass.
+// The resolver checks that for traits declared in the same module, so only imported ones are checked here
+func mixTraits(stmt *ast.ClassDeclStmt, traits []*Trait, superclass *Class, methods map[string]ClassMethod, check bool) error {
+	providers := make(map[string]*Trait)
+	for idx, trait := range traits {
+		for _, name := range slices.Sorted(maps.Keys(trait.Methods)) {
+			if _, ok := methods[name]; ok {
+				continue
+			}
+			if other, ok := providers[name]; ok {
+				if other != trait && check {
+					return util.ReportErrorOnToken(stmt.Traits[idx].Name, "method '%v' is defined by both traits '%v' and '%v', class '%v' must override it",
+						name, other.Name, trait.Name, stmt.Name.Lexeme)
+				}
+				continue
+			}
+			providers[name] = trait
+		}
+	}
+
+	for name, trait := range providers {
+		methods[name] = ClassMethod{
+			Callable: trait.Methods[name],
+		}
+	}
+
+	if !check {
+		return nil
+	}
+	for _, trait := range traits {
+		for _, required := range trait.Required {
+			if !implementsMethod(stmt, superclass, methods, required.Name.Lexeme) {
+				return util.ReportErrorOnToken(stmt.Name, "class '%v' must implement method '%v' required by trait '%v'",
+					stmt.Name.Lexeme, required.Name.Lexeme, trait.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// implementsMethod reports whether the class declared by stmt has an instance method name,
+// abstract methods count as implemented, subclasses have to implement them
+func implementsMethod(stmt *ast.ClassDeclStmt, superclass *Class, methods map[string]ClassMethod, name string) bool {
+	if method, ok := methods[name]; ok {
+		return !method.Static && !method.IsAccessor()
+	}
+	for _, method := range stmt.Methods {
+		if method.Abstract && method.Func.Name.Lexeme == name {
+			return true
+		}
+	}
+
+	if superclass == nil {
+		return false
+	}
+	_, ok := superclass.FindMethod(name)
+	_, abstract := superclass.Abstract[name]
+	return ok || abstract
+}
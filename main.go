@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Valeron93/crafting-interpreters/ast"
 	"github.com/Valeron93/crafting-interpreters/interpreter"
 	"github.com/Valeron93/crafting-interpreters/parser"
 	"github.com/Valeron93/crafting-interpreters/resolver"
@@ -65,6 +66,11 @@ func runFile(path string) {
 		os.Exit(1)
 	}
 	interpreter := interpreter.New()
+	interpreter.SetModuleResolver(resolveModule)
+	if err := interpreter.SetEntryFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open file: %v\n", err)
+		os.Exit(1)
+	}
 	resolver := resolver.New(&interpreter)
 	if ok := runString(&interpreter, resolver, string(bytes), path); !ok {
 		os.Exit(1)
@@ -74,7 +80,12 @@ func runFile(path string) {
 var replInterpreter = interpreter.New()
 var replResolver = resolver.New(&replInterpreter)
 
+func resolveModule(i *interpreter.Interpreter, stmts []ast.Stmt) []error {
+	return resolver.New(i).ResolveStatements(stmts)
+}
+
 func runPrompt() {
+	replInterpreter.SetModuleResolver(resolveModule)
 
 	rl, err := readline.New("> ")
 	if err != nil {
@@ -73,12 +73,30 @@ func (p *Parser) consume(typ scanner.TokenType, msg string) (scanner.Token, erro
 	}
 }
 
+// checkWord reports whether the next token is the contextual keyword word
+func (p *Parser) checkWord(word string) bool {
+	return p.check(scanner.Ident) && p.peek().Lexeme == word
+}
+
+// checkNextWord reports whether the token after the next one is the contextual keyword word
+func (p *Parser) checkNextWord(word string) bool {
+	return p.checkNext(scanner.Ident) && p.tokens[p.current+1].Lexeme == word
+}
+
+// consumeWord consumes a contextual keyword like 'as' in imports, which can still be a variable name
+func (p *Parser) consumeWord(word string, msg string) (scanner.Token, error) {
+	if p.checkWord(word) {
+		return p.advance(), nil
+	}
+	return scanner.Token{}, util.ReportErrorOnToken(p.prev(), "%v", msg)
+}
+
 func (p *Parser) expression() (ast.Expr, error) {
 	return p.assignment()
 }
 
 func (p *Parser) assignment() (ast.Expr, error) {
-	expr, err := p.or()
+	expr, err := p.conditional()
 	if err != nil {
 		return nil, err
 	}
@@ -111,13 +129,119 @@ func (p *Parser) assignment() (ast.Expr, error) {
 				Value:   value,
 				Bracket: expr.Bracket,
 			}, nil
+
+		case *ast.ListExpr:
+			if err := checkAssignmentTargets(expr); err != nil {
+				return nil, err
+			}
+			return &ast.DestructureExpr{
+				Target: expr,
+				Equals: equals,
+				Value:  value,
+			}, nil
 		}
 
 		return nil, util.ReportErrorOnToken(equals, "invalid assignment")
 	}
+
+	if p.match(scanner.PlusEqual, scanner.MinusEqual, scanner.StarEqual, scanner.SlashEqual, scanner.PercentEqual) {
+		operator := p.prev()
+		value, err := p.assignment()
+		if err != nil {
+			return nil, err
+		}
+
+		if !isAssignable(expr) {
+			return nil, util.ReportErrorOnToken(operator, "invalid assignment")
+		}
+
+		return &ast.CompoundAssignExpr{
+			Target:   expr,
+			Operator: operator,
+			Value:    value,
+		}, nil
+	}
 	return expr, nil
 }
 
+func isAssignable(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.VarExpr, *ast.GetExpr, *ast.GetKeyExpr:
+		return true
+	}
+	return false
+}
+
+// checkAssignmentTargets reports elements of `[a, b] = value` which can't be assigned to,
+// nested lists are destructured too and `...rest` may only be the last element
+func checkAssignmentTargets(list *ast.ListExpr) error {
+	for idx, element := range list.Elements {
+		if spread, ok := element.(*ast.SpreadExpr); ok {
+			if idx != len(list.Elements)-1 {
+				return util.ReportErrorOnToken(spread.Ellipsis, "'...' must be the last assignment target")
+			}
+			element = spread.Value
+		}
+
+		if nested, ok := element.(*ast.ListExpr); ok {
+			if err := checkAssignmentTargets(nested); err != nil {
+				return err
+			}
+		} else if !isAssignable(element) {
+			return util.ReportErrorOnToken(list.Bracket, "invalid assignment target in list")
+		}
+	}
+	return nil
+}
+
+// increment creates `target += 1` or `target -= 1` for '++' and '--'
+func increment(operator scanner.Token, target ast.Expr, postfix bool) (ast.Expr, error) {
+	if !isAssignable(target) {
+		return nil, util.ReportErrorOnToken(operator, "operand of '%v' must be a variable, a field or an index", operator.Lexeme)
+	}
+
+	return &ast.CompoundAssignExpr{
+		Target:   target,
+		Operator: operator,
+		Value:    &ast.LiteralExpr{Value: int64(1)},
+		Postfix:  postfix,
+	}, nil
+}
+
+// conditional is right-associative, `a ? b : c ? d : e` is `a ? b : (c ? d : e)`
+func (p *Parser) conditional() (ast.Expr, error) {
+	condition, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(scanner.Question) {
+		question := p.prev()
+		thenExpr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.consume(scanner.Colon, "expected ':' after then branch of conditional expression")
+		if err != nil {
+			return nil, err
+		}
+
+		elseExpr, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.ConditionalExpr{
+			Condition: condition,
+			Question:  question,
+			Then:      thenExpr,
+			Else:      elseExpr,
+		}, nil
+	}
+	return condition, nil
+}
+
 func (p *Parser) or() (ast.Expr, error) {
 	expr, err := p.and()
 	if err != nil {
@@ -185,12 +309,96 @@ func (p *Parser) equality() (ast.Expr, error) {
 }
 
 func (p *Parser) comparison() (ast.Expr, error) {
-	expression, err := p.term()
+	expression, err := p.bitwiseOr()
 	if err != nil {
 		return nil, err
 	}
 
 	for p.match(scanner.Greater, scanner.GreaterEqual, scanner.Less, scanner.LessEqual) {
+		op := p.prev()
+		right, err := p.bitwiseOr()
+		if err != nil {
+			return nil, err
+		}
+		expression = &ast.BinaryExpr{
+			Left:     expression,
+			Operator: op,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+func (p *Parser) bitwiseOr() (ast.Expr, error) {
+	expression, err := p.bitwiseXor()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(scanner.Pipe) {
+		op := p.prev()
+		right, err := p.bitwiseXor()
+		if err != nil {
+			return nil, err
+		}
+		expression = &ast.BinaryExpr{
+			Left:     expression,
+			Operator: op,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+func (p *Parser) bitwiseXor() (ast.Expr, error) {
+	expression, err := p.bitwiseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(scanner.Caret) {
+		op := p.prev()
+		right, err := p.bitwiseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expression = &ast.BinaryExpr{
+			Left:     expression,
+			Operator: op,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+func (p *Parser) bitwiseAnd() (ast.Expr, error) {
+	expression, err := p.shift()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(scanner.Ampersand) {
+		op := p.prev()
+		right, err := p.shift()
+		if err != nil {
+			return nil, err
+		}
+		expression = &ast.BinaryExpr{
+			Left:     expression,
+			Operator: op,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+func (p *Parser) shift() (ast.Expr, error) {
+	expression, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(scanner.LessLess, scanner.GreaterGreater) {
 		op := p.prev()
 		right, err := p.term()
 		if err != nil {
@@ -232,7 +440,7 @@ func (p *Parser) factor() (ast.Expr, error) {
 		return nil, err
 	}
 
-	for p.match(scanner.Star, scanner.Slash) {
+	for p.match(scanner.Star, scanner.Slash, scanner.TildeSlash, scanner.Percent) {
 		op := p.prev()
 		right, err := p.unary()
 		if err != nil {
@@ -248,7 +456,16 @@ func (p *Parser) factor() (ast.Expr, error) {
 }
 
 func (p *Parser) unary() (ast.Expr, error) {
-	if p.match(scanner.Bang, scanner.Minus) {
+	if p.match(scanner.PlusPlus, scanner.MinusMinus) {
+		op := p.prev()
+		target, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return increment(op, target, false)
+	}
+
+	if p.match(scanner.Bang, scanner.Minus, scanner.Tilde) {
 		op := p.prev()
 		right, err := p.unary()
 		return &ast.UnaryExpr{
@@ -256,7 +473,42 @@ func (p *Parser) unary() (ast.Expr, error) {
 			Right:    right,
 		}, err
 	}
-	return p.call()
+	return p.power()
+}
+
+// power binds tighter than unary operators and is right-associative,
+// so `-2 ** 2` is `-(2 ** 2)` and `2 ** 3 ** 2` is `2 ** (3 ** 2)`
+func (p *Parser) power() (ast.Expr, error) {
+	expression, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(scanner.StarStar) {
+		op := p.prev()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		expression = &ast.BinaryExpr{
+			Left:     expression,
+			Operator: op,
+			Right:    right,
+		}
+	}
+	return expression, nil
+}
+
+func (p *Parser) postfix() (ast.Expr, error) {
+	expression, err := p.call()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(scanner.PlusPlus, scanner.MinusMinus) {
+		return increment(p.prev(), expression, true)
+	}
+	return expression, nil
 }
 
 func (p *Parser) call() (ast.Expr, error) {
@@ -273,7 +525,7 @@ func (p *Parser) call() (ast.Expr, error) {
 				return nil, err
 			}
 		} else if p.match(scanner.Dot) {
-			name, err := p.consume(scanner.Ident, "expected getter after '.'")
+			name, err := p.memberName("expected getter after '.'")
 			if err != nil {
 				return nil, err
 			}
@@ -310,24 +562,27 @@ func (p *Parser) call() (ast.Expr, error) {
 
 func (p *Parser) finishCall(callee ast.Expr) (ast.Expr, error) {
 	args := make([]ast.Expr, 0, 1)
+	names := make([]*scanner.Token, 0, 1)
 	if !p.check(scanner.RightParen) {
 
-		expr, err := p.expression()
-		if err != nil {
-			return nil, err
-		}
-		args = append(args, expr)
-
-		for p.match(scanner.Comma) {
+		for {
 			if len(args) >= 127 {
 				return nil, util.ReportErrorOnToken(p.peek(), "function call has a limit of 127 arguments")
 			}
 
-			expr, err := p.expression()
+			name, expr, err := p.argument()
 			if err != nil {
 				return nil, err
 			}
+			if name == nil && len(names) > 0 && names[len(names)-1] != nil {
+				return nil, util.ReportErrorOnToken(p.prev(), "positional argument can't follow keyword arguments")
+			}
 			args = append(args, expr)
+			names = append(names, name)
+
+			if !p.match(scanner.Comma) {
+				break
+			}
 		}
 	}
 
@@ -339,9 +594,35 @@ func (p *Parser) finishCall(callee ast.Expr) (ast.Expr, error) {
 		Callee: callee,
 		Paren:  paren,
 		Args:   args,
+		Names:  names,
 	}, nil
 }
 
+// argument parses a call argument, which is either positional, `name: value` or `...iterable`
+func (p *Parser) argument() (*scanner.Token, ast.Expr, error) {
+	if p.check(scanner.Ellipsis) {
+		ellipsis := p.advance()
+		value, err := p.expression()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &ast.SpreadExpr{
+			Ellipsis: ellipsis,
+			Value:    value,
+		}, nil
+	}
+
+	var name *scanner.Token
+	if p.check(scanner.Ident) && p.checkNext(scanner.Colon) {
+		token := p.advance()
+		p.advance()
+		name = &token
+	}
+
+	expr, err := p.expression()
+	return name, expr, err
+}
+
 func (p *Parser) primary() (ast.Expr, error) {
 	if p.match(scanner.False) {
 		return &ast.LiteralExpr{
@@ -367,6 +648,10 @@ func (p *Parser) primary() (ast.Expr, error) {
 		}, nil
 	}
 
+	if p.match(scanner.Interpolation) {
+		return p.interpolation()
+	}
+
 	if p.match(scanner.This) {
 		return &ast.ThisExpr{
 			Keyword: p.prev(),
@@ -414,76 +699,651 @@ func (p *Parser) primary() (ast.Expr, error) {
 		return p.lambdaFunction()
 	}
 
-	return nil, util.ReportErrorOnToken(p.prev(), "expected expression, got '%v'", p.peek().Lexeme)
-}
-
-func (p *Parser) statement() (ast.Stmt, error) {
-	if p.match(scanner.If) {
-		return p.ifStatement()
+	if p.match(scanner.LeftBracket) {
+		return p.listLiteral()
 	}
 
-	if p.match(scanner.While) {
-		return p.whileStatement()
+	// blocks are handled in p.statement, so '{' in expression position is always a map
+	if p.match(scanner.LeftBrace) {
+		return p.mapLiteral()
 	}
 
-	if p.match(scanner.Return) {
-		return p.returnStatement()
-	}
+	return nil, util.ReportErrorOnToken(p.prev(), "expected expression, got '%v'", p.peek().Lexeme)
+}
 
-	if p.match(scanner.For) {
-		return p.forStatement()
-	}
+func (p *Parser) interpolation() (ast.Expr, error) {
+	token := p.prev()
+	parts := make([]ast.Expr, 0)
 
-	if p.match(scanner.LeftBrace) {
-		stmts, err := p.block()
+	for _, part := range token.Literal.([]scanner.InterpolationPart) {
+		if part.Tokens == nil {
+			if len(part.Text) > 0 {
+				parts = append(parts, &ast.LiteralExpr{Value: part.Text})
+			}
+			continue
+		}
+
+		sub := NewParser(part.Tokens)
+		if sub.isAtEnd() {
+			return nil, util.ReportErrorOnToken(token, "expected expression inside '${}'")
+		}
+
+		expr, err := sub.expression()
 		if err != nil {
 			return nil, err
 		}
-		return &ast.BlockStmt{
-			Statements: stmts,
-		}, nil
+
+		if !sub.isAtEnd() {
+			return nil, util.ReportErrorOnToken(sub.peek(), "expected '}' after interpolated expression, got '%v'", sub.peek().Lexeme)
+		}
+		parts = append(parts, expr)
 	}
 
-	return p.expressionStatement()
+	return &ast.InterpolationExpr{
+		Token: token,
+		Parts: parts,
+	}, nil
 }
 
-func (p *Parser) returnStatement() (ast.Stmt, error) {
-	token := p.prev()
-	var value ast.Expr
+func (p *Parser) listLiteral() (ast.Expr, error) {
+	bracket := p.prev()
+	elements := make([]ast.Expr, 0)
 
-	if !p.check(scanner.Semicolon) {
+	for !p.check(scanner.RightBracket) {
+		var element ast.Expr
 		var err error
-		value, err = p.expression()
+		if p.check(scanner.Ellipsis) {
+			ellipsis := p.advance()
+			element, err = p.expression()
+			element = &ast.SpreadExpr{
+				Ellipsis: ellipsis,
+				Value:    element,
+			}
+		} else {
+			element, err = p.expression()
+		}
 		if err != nil {
-			return nil, util.ReportErrorOnToken(token, "expected expression or ';' after return statement")
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		if !p.match(scanner.Comma) {
+			break
 		}
 	}
 
-	_, err := p.consume(scanner.Semicolon, "expected ';' after return statement")
+	_, err := p.consume(scanner.RightBracket, "expected ']' after list elements")
 	if err != nil {
 		return nil, err
 	}
-	return &ast.ReturnStmt{
-		Token: token,
-		Value: value,
+
+	return &ast.ListExpr{
+		Bracket:  bracket,
+		Elements: elements,
 	}, nil
 }
 
-func (p *Parser) forStatement() (ast.Stmt, error) {
-	_, err := p.consume(scanner.LeftParen, "expected '(' after for")
-	if err != nil {
-		return nil, err
-	}
+func (p *Parser) mapLiteral() (ast.Expr, error) {
+	brace := p.prev()
+	keys := make([]ast.Expr, 0)
+	values := make([]ast.Expr, 0)
 
-	var init ast.Stmt
+	for !p.check(scanner.RightBrace) {
+		key, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
 
-	if p.match(scanner.Semicolon) {
-		init = nil
-	} else if p.match(scanner.Var) {
-		init, err = p.varDeclaration()
-	} else {
-		init, err = p.expressionStatement()
-	}
+		_, err = p.consume(scanner.Colon, "expected ':' after map key")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+
+		if !p.match(scanner.Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(scanner.RightBrace, "expected '}' after map entries")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.MapExpr{
+		Brace:  brace,
+		Keys:   keys,
+		Values: values,
+	}, nil
+}
+
+func (p *Parser) statement() (ast.Stmt, error) {
+	if p.match(scanner.If) {
+		return p.ifStatement()
+	}
+
+	if p.match(scanner.While) {
+		return p.whileStatement()
+	}
+
+	if p.match(scanner.Return) {
+		return p.returnStatement()
+	}
+
+	if p.match(scanner.For) {
+		return p.forStatement()
+	}
+
+	if p.match(scanner.Break) {
+		return p.breakStatement()
+	}
+
+	if p.match(scanner.Continue) {
+		return p.continueStatement()
+	}
+
+	if p.match(scanner.Throw) {
+		return p.throwStatement()
+	}
+
+	if p.match(scanner.Try) {
+		return p.tryStatement()
+	}
+
+	if p.isMatchStatement() {
+		p.advance()
+		return p.matchStatement()
+	}
+
+	if p.match(scanner.LeftBrace) {
+		stmts, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		return &ast.BlockStmt{
+			Statements: stmts,
+		}, nil
+	}
+
+	return p.expressionStatement()
+}
+
+func (p *Parser) returnStatement() (ast.Stmt, error) {
+	token := p.prev()
+	var value ast.Expr
+
+	if !p.check(scanner.Semicolon) {
+		var err error
+		value, err = p.expression()
+		if err != nil {
+			return nil, util.ReportErrorOnToken(token, "expected expression or ';' after return statement")
+		}
+	}
+
+	_, err := p.consume(scanner.Semicolon, "expected ';' after return statement")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ReturnStmt{
+		Token: token,
+		Value: value,
+	}, nil
+}
+
+func (p *Parser) breakStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+	_, err := p.consume(scanner.Semicolon, "expected ';' after 'break'")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.BreakStmt{
+		Keyword: keyword,
+	}, nil
+}
+
+func (p *Parser) continueStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+	_, err := p.consume(scanner.Semicolon, "expected ';' after 'continue'")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ContinueStmt{
+		Keyword: keyword,
+	}, nil
+}
+
+func (p *Parser) throwStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+	value, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(scanner.Semicolon, "expected ';' after throw statement")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ThrowStmt{
+		Keyword: keyword,
+		Value:   value,
+	}, nil
+}
+
+func (p *Parser) tryStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+
+	_, err := p.consume(scanner.LeftBrace, "expected '{' after 'try'")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	var catchName *scanner.Token
+	var catch []ast.Stmt
+	if p.match(scanner.Catch) {
+		_, err = p.consume(scanner.LeftParen, "expected '(' after 'catch'")
+		if err != nil {
+			return nil, err
+		}
+		name, err := p.consume(scanner.Ident, "expected exception variable name")
+		if err != nil {
+			return nil, err
+		}
+		catchName = &name
+
+		_, err = p.consume(scanner.RightParen, "expected ')' after exception variable name")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(scanner.LeftBrace, "expected '{' before catch body")
+		if err != nil {
+			return nil, err
+		}
+		catch, err = p.block()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var finally []ast.Stmt
+	if p.match(scanner.Finally) {
+		_, err = p.consume(scanner.LeftBrace, "expected '{' after 'finally'")
+		if err != nil {
+			return nil, err
+		}
+		finally, err = p.block()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if catchName == nil && finally == nil {
+		return nil, util.ReportErrorOnToken(keyword, "expected 'catch' or 'finally' after try block")
+	}
+
+	return &ast.TryStmt{
+		Keyword:   keyword,
+		Body:      body,
+		CatchName: catchName,
+		Catch:     catch,
+		Finally:   finally,
+	}, nil
+}
+
+// isMatchStatement reports whether the next tokens are `match (value) {`,
+// `match` is a contextual keyword, so `match(value);` is still a call of a function named match
+func (p *Parser) isMatchStatement() bool {
+	if !p.checkWord("match") || !p.checkNext(scanner.LeftParen) {
+		return false
+	}
+
+	depth := 0
+	for idx := p.current + 1; idx < len(p.tokens); idx++ {
+		switch p.tokens[idx].Type {
+		case scanner.LeftParen:
+			depth++
+		case scanner.RightParen:
+			depth--
+			if depth == 0 {
+				return idx+1 < len(p.tokens) && p.tokens[idx+1].Type == scanner.LeftBrace
+			}
+		case scanner.EOF:
+			return false
+		}
+	}
+	return false
+}
+
+func (p *Parser) matchStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+
+	_, err := p.consume(scanner.LeftParen, "expected '(' after 'match'")
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(scanner.RightParen, "expected ')' after match value")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(scanner.LeftBrace, "expected '{' before match arms")
+	if err != nil {
+		return nil, err
+	}
+
+	arms := make([]*ast.MatchArm, 0)
+	for !p.check(scanner.RightBrace) && !p.isAtEnd() {
+		arm, err := p.matchArm()
+		if err != nil {
+			return nil, err
+		}
+		arms = append(arms, arm)
+
+		// arms with a block body don't need a ',' after them
+		_, isBlock := arm.Body.(*ast.BlockStmt)
+		if !p.match(scanner.Comma) && !isBlock {
+			break
+		}
+	}
+
+	_, err = p.consume(scanner.RightBrace, "expected '}' after match arms")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.MatchStmt{
+		Keyword: keyword,
+		Value:   value,
+		Arms:    arms,
+	}, nil
+}
+
+func (p *Parser) matchArm() (*ast.MatchArm, error) {
+	pattern, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	var guard ast.Expr
+	if p.match(scanner.If) {
+		guard, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = p.consume(scanner.Arrow, "expected '=>' after match pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	var body ast.Stmt
+	if p.match(scanner.LeftBrace) {
+		stmts, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		body = &ast.BlockStmt{
+			Statements: stmts,
+		}
+	} else {
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		body = &ast.ExprStmt{
+			Expr: expr,
+		}
+	}
+
+	return &ast.MatchArm{
+		Pattern: pattern,
+		Guard:   guard,
+		Body:    body,
+	}, nil
+}
+
+func (p *Parser) pattern() (ast.Pattern, error) {
+	if p.match(scanner.Number, scanner.String) {
+		return &ast.LiteralPattern{
+			Token: p.prev(),
+			Value: p.prev().Literal,
+		}, nil
+	}
+
+	if p.match(scanner.Minus) {
+		number, err := p.consume(scanner.Number, "expected number after '-' in pattern")
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		switch literal := number.Literal.(type) {
+		case int64:
+			value = -literal
+		case float64:
+			value = -literal
+		}
+		return &ast.LiteralPattern{
+			Token: number,
+			Value: value,
+		}, nil
+	}
+
+	if p.match(scanner.True) {
+		return &ast.LiteralPattern{
+			Token: p.prev(),
+			Value: true,
+		}, nil
+	}
+
+	if p.match(scanner.False) {
+		return &ast.LiteralPattern{
+			Token: p.prev(),
+			Value: false,
+		}, nil
+	}
+
+	if p.match(scanner.Nil) {
+		return &ast.LiteralPattern{
+			Token: p.prev(),
+			Value: nil,
+		}, nil
+	}
+
+	if p.match(scanner.Ident) {
+		name := p.prev()
+
+		if name.Lexeme == "_" {
+			return &ast.WildcardPattern{
+				Token: name,
+			}, nil
+		}
+
+		if !p.match(scanner.LeftParen) {
+			return &ast.BindingPattern{
+				Name: name,
+			}, nil
+		}
+
+		fields := make([]scanner.Token, 0)
+		for !p.check(scanner.RightParen) {
+			field, err := p.consume(scanner.Ident, "expected field name in class pattern")
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+
+			if !p.match(scanner.Comma) {
+				break
+			}
+		}
+
+		_, err := p.consume(scanner.RightParen, "expected ')' after class pattern fields")
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.ClassPattern{
+			Class:  &ast.VarExpr{Name: name},
+			Fields: fields,
+		}, nil
+	}
+
+	if p.match(scanner.LeftBracket) {
+		return p.listPattern()
+	}
+
+	if p.match(scanner.LeftBrace) {
+		return p.objectPattern()
+	}
+
+	return nil, util.ReportErrorOnToken(p.peek(), "expected pattern, got '%v'", p.peek().Lexeme)
+}
+
+func (p *Parser) listPattern() (ast.Pattern, error) {
+	bracket := p.prev()
+	elements := make([]ast.Pattern, 0)
+	var rest *scanner.Token
+
+	for !p.check(scanner.RightBracket) {
+		if p.match(scanner.Ellipsis) {
+			name, err := p.consume(scanner.Ident, "expected name after '...' in list pattern")
+			if err != nil {
+				return nil, err
+			}
+			rest = &name
+			break
+		}
+
+		element, err := p.defaultPattern()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		if !p.match(scanner.Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(scanner.RightBracket, "expected ']' after list pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ListPattern{
+		Bracket:  bracket,
+		Elements: elements,
+		Rest:     rest,
+	}, nil
+}
+
+// objectPattern parses `{name, age: years}`, a key without a pattern binds a variable of the same name
+func (p *Parser) objectPattern() (ast.Pattern, error) {
+	brace := p.prev()
+	keys := make([]scanner.Token, 0)
+	values := make([]ast.Pattern, 0)
+
+	for !p.check(scanner.RightBrace) {
+		key, err := p.consume(scanner.Ident, "expected key in object pattern")
+		if err != nil {
+			return nil, err
+		}
+
+		var value ast.Pattern = &ast.BindingPattern{Name: key}
+		if p.match(scanner.Colon) {
+			value, err = p.pattern()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if p.match(scanner.Equal) {
+			def, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			value = &ast.DefaultPattern{
+				Pattern: value,
+				Default: def,
+			}
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+
+		if !p.match(scanner.Comma) {
+			break
+		}
+	}
+
+	_, err := p.consume(scanner.RightBrace, "expected '}' after object pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ObjectPattern{
+		Brace:  brace,
+		Keys:   keys,
+		Values: values,
+	}, nil
+}
+
+// defaultPattern parses a pattern optionally followed by `= default`
+func (p *Parser) defaultPattern() (ast.Pattern, error) {
+	pattern, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.match(scanner.Equal) {
+		return pattern, nil
+	}
+
+	def, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.DefaultPattern{
+		Pattern: pattern,
+		Default: def,
+	}, nil
+}
+
+func (p *Parser) forStatement() (ast.Stmt, error) {
+	keyword := p.prev()
+	_, err := p.consume(scanner.LeftParen, "expected '(' after for")
+	if err != nil {
+		return nil, err
+	}
+
+	var init ast.Stmt
+
+	if p.match(scanner.Semicolon) {
+		init = nil
+	} else if p.match(scanner.Var) {
+		if p.check(scanner.Ident) && p.checkNextWord("in") {
+			return p.forInStatement(keyword)
+		}
+		init, err = p.varDeclaration()
+	} else {
+		init, err = p.expressionStatement()
+	}
 
 	if err != nil {
 		return nil, err
@@ -518,18 +1378,16 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 		return nil, err
 	}
 
-	if incr != nil {
-		body = &ast.BlockStmt{
-			Statements: []ast.Stmt{body, &ast.ExprStmt{Expr: incr}},
-		}
-	}
-
 	if cond == nil {
 		cond = &ast.LiteralExpr{Value: true}
 	}
+
+	// the increment is kept separate from the body,
+	// so that 'continue' does not skip it
 	body = &ast.WhileStmt{
 		Condition: cond,
 		Body:      body,
+		Increment: incr,
 	}
 
 	if init != nil {
@@ -543,6 +1401,33 @@ func (p *Parser) forStatement() (ast.Stmt, error) {
 	return body, nil
 }
 
+func (p *Parser) forInStatement(keyword scanner.Token) (ast.Stmt, error) {
+	name := p.advance()
+	p.advance() // consume 'in'
+
+	iterable, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(scanner.RightParen, "expected ')' after for")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ForInStmt{
+		Keyword:  keyword,
+		Name:     name,
+		Iterable: iterable,
+		Body:     body,
+	}, nil
+}
+
 func (p *Parser) whileStatement() (ast.Stmt, error) {
 
 	_, err := p.consume(scanner.LeftParen, "expected '(' after while")
@@ -625,21 +1510,130 @@ func (p *Parser) ifStatement() (ast.Stmt, error) {
 
 func (p *Parser) declaration() (ast.Stmt, error) {
 	if p.check(scanner.Func) && p.checkNext(scanner.Ident) {
-		p.match(scanner.Func)
-		return p.function("function")
+		doc := p.advance().Doc
+		fn, err := p.function("function")
+		if err != nil {
+			return nil, err
+		}
+		fn.Doc = doc
+		return fn, nil
 	}
 
 	if p.match(scanner.Class) {
 		return p.classDeclaration()
 	}
 
+	if p.isTraitDeclaration() {
+		p.advance()
+		return p.traitDeclaration()
+	}
+
 	if p.match(scanner.Var) {
 		return p.varDeclaration()
 	}
+
+	if p.match(scanner.Import) {
+		return p.importDeclaration()
+	}
+
+	if p.match(scanner.Export) {
+		return p.exportDeclaration()
+	}
 	return p.statement()
 }
 
+func (p *Parser) importDeclaration() (ast.Stmt, error) {
+	keyword := p.prev()
+
+	var names []scanner.Token
+	if p.match(scanner.LeftBrace) {
+		names = make([]scanner.Token, 0)
+		for {
+			name, err := p.consume(scanner.Ident, "expected imported name")
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+
+			if !p.match(scanner.Comma) {
+				break
+			}
+		}
+
+		_, err := p.consume(scanner.RightBrace, "expected '}' after imported names")
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = p.consumeWord("from", "expected 'from' after imported names")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path, err := p.consume(scanner.String, "expected module path")
+	if err != nil {
+		return nil, err
+	}
+
+	var alias *scanner.Token
+	if names == nil {
+		_, err = p.consumeWord("as", "expected 'as' after module path")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := p.consume(scanner.Ident, "expected module name after 'as'")
+		if err != nil {
+			return nil, err
+		}
+		alias = &name
+	}
+
+	_, err = p.consume(scanner.Semicolon, "expected ';' after import")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.ImportStmt{
+		Keyword: keyword,
+		Path:    path,
+		Alias:   alias,
+		Names:   names,
+	}, nil
+}
+
+func (p *Parser) exportDeclaration() (ast.Stmt, error) {
+	keyword := p.prev()
+
+	isFunction := p.check(scanner.Func) && p.checkNext(scanner.Ident)
+	if !isFunction && !p.check(scanner.Class) && !p.isTraitDeclaration() && !p.check(scanner.Var) {
+		return nil, util.ReportErrorOnToken(keyword, "only function, class, trait and variable declarations can be exported")
+	}
+
+	decl, err := p.declaration()
+	if err != nil {
+		return nil, err
+	}
+
+	// doc comments are written before `export`
+	switch decl := decl.(type) {
+	case *ast.FuncDeclStmt:
+		decl.Doc = keyword.Doc
+	case *ast.ClassDeclStmt:
+		decl.Doc = keyword.Doc
+	case *ast.TraitDeclStmt:
+		decl.Doc = keyword.Doc
+	}
+
+	return &ast.ExportStmt{
+		Keyword: keyword,
+		Decl:    decl,
+	}, nil
+}
+
 func (p *Parser) classDeclaration() (ast.Stmt, error) {
+	doc := p.prev().Doc
 	name, err := p.consume(scanner.Ident, "expected class name")
 	if err != nil {
 		return nil, err
@@ -656,16 +1650,75 @@ func (p *Parser) classDeclaration() (ast.Stmt, error) {
 		}
 	}
 
+	var traits []*ast.VarExpr
+	if p.checkWord("with") {
+		p.advance()
+		for {
+			traitName, err := p.consume(scanner.Ident, "expected trait name after 'with'")
+			if err != nil {
+				return nil, err
+			}
+			traits = append(traits, &ast.VarExpr{
+				Name: traitName,
+			})
+
+			if !p.match(scanner.Comma) {
+				break
+			}
+		}
+	}
+
 	_, err = p.consume(scanner.LeftBrace, "expected '{' before class body")
 	if err != nil {
 		return nil, err
 	}
 
 	methods := make([]*ast.MethodDeclStmt, 0)
+	fields := make([]*ast.VarStmt, 0)
+	staticFields := make([]*ast.VarStmt, 0)
 	for !p.check(scanner.RightBrace) && !p.isAtEnd() {
+		doc := p.peek().Doc
+
+		if p.check(scanner.Var) || p.check(scanner.Static) && p.checkNext(scanner.Var) {
+			static := p.match(scanner.Static)
+			field, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			if static && field.Name.Type == scanner.PrivateIdent {
+				return nil, util.ReportErrorOnToken(field.Name, "static fields can't be private")
+			}
+			if static {
+				staticFields = append(staticFields, field)
+			} else {
+				fields = append(fields, field)
+			}
+			continue
+		}
+
+		if (p.checkWord("get") || p.checkWord("set")) && p.checkNext(scanner.Ident) {
+			accessor, err := p.accessor()
+			if err != nil {
+				return nil, err
+			}
+			accessor.Func.Doc = doc
+			methods = append(methods, accessor)
+			continue
+		}
+
+		if p.checkWord("abstract") && p.checkNext(scanner.Func) {
+			method, err := p.abstractMethod()
+			if err != nil {
+				return nil, err
+			}
+			method.Func.Doc = doc
+			methods = append(methods, method)
+			continue
+		}
+
 		static := p.match(scanner.Static)
 
-		if !p.check(scanner.Func) || !p.checkNext(scanner.Ident) {
+		if !p.check(scanner.Func) || !p.checkNext(scanner.Ident) && !p.checkNext(scanner.PrivateIdent) {
 			return nil, util.ReportErrorOnToken(p.peek(), "only method declarations are allowed in class declaration")
 		}
 		p.match(scanner.Func)
@@ -673,6 +1726,10 @@ func (p *Parser) classDeclaration() (ast.Stmt, error) {
 		if err != nil {
 			return nil, err
 		}
+		if static && fn.Name.Type == scanner.PrivateIdent {
+			return nil, util.ReportErrorOnToken(fn.Name, "static methods can't be private")
+		}
+		fn.Doc = doc
 		methods = append(methods, &ast.MethodDeclStmt{
 			Func:   fn,
 			Static: static,
@@ -681,74 +1738,263 @@ func (p *Parser) classDeclaration() (ast.Stmt, error) {
 
 	_, err = p.consume(scanner.RightBrace, "expected '}' after class body")
 	return &ast.ClassDeclStmt{
-		Name:       name,
-		Methods:    methods,
-		Superclass: superclass,
+		Name:         name,
+		Methods:      methods,
+		Superclass:   superclass,
+		Doc:          doc,
+		Fields:       fields,
+		StaticFields: staticFields,
+		Traits:       traits,
 	}, err
 }
 
-func (p *Parser) params() ([]scanner.Token, error) {
+// isTraitDeclaration reports whether the next tokens are `trait Name`, trait is a contextual keyword
+func (p *Parser) isTraitDeclaration() bool {
+	return p.checkWord("trait") && p.checkNext(scanner.Ident)
+}
 
-	_, err := p.consume(scanner.LeftParen, "expected '(' before parameter list")
+// traitDeclaration parses a trait body of methods and required method signatures `fn name(params);`
+func (p *Parser) traitDeclaration() (ast.Stmt, error) {
+	doc := p.prev().Doc
+	name, err := p.consume(scanner.Ident, "expected trait name")
 	if err != nil {
 		return nil, err
 	}
 
-	params := make([]scanner.Token, 0)
-	if !p.check(scanner.RightParen) {
+	_, err = p.consume(scanner.LeftBrace, "expected '{' before trait body")
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make([]*ast.FuncDeclStmt, 0)
+	required := make([]*ast.FuncDeclStmt, 0)
+	for !p.check(scanner.RightBrace) && !p.isAtEnd() {
+		doc := p.peek().Doc
+		if !p.check(scanner.Func) || !p.checkNext(scanner.Ident) {
+			return nil, util.ReportErrorOnToken(p.peek(), "only method declarations are allowed in trait declaration")
+		}
+		p.advance()
+
+		fn, err := p.signature("method")
+		if err != nil {
+			return nil, err
+		}
+		fn.Doc = doc
+
+		if p.match(scanner.Semicolon) {
+			required = append(required, fn)
+			continue
+		}
+
+		err = p.body(fn, "method")
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, fn)
+	}
+
+	_, err = p.consume(scanner.RightBrace, "expected '}' after trait body")
+	return &ast.TraitDeclStmt{
+		Name:     name,
+		Methods:  methods,
+		Required: required,
+		Doc:      doc,
+	}, err
+}
+
+// field parses a field declaration `let name = value;` of a class body
+func (p *Parser) field() (*ast.VarStmt, error) {
+	p.advance()
+	name, err := p.memberName("expected field name")
+	if err != nil {
+		return nil, err
+	}
 
-		param, err := p.consume(scanner.Ident, "expected parameter name")
+	var init ast.Expr
+	if p.match(scanner.Equal) {
+		init, err = p.expression()
 		if err != nil {
 			return nil, err
 		}
-		params = append(params, param)
+	}
+
+	_, err = p.consume(scanner.Semicolon, "expected ';' after field declaration")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.VarStmt{
+		Name: name,
+		Init: init,
+	}, nil
+}
+
+// memberName consumes the name of a class member, which is private when it starts with '#'
+func (p *Parser) memberName(msg string) (scanner.Token, error) {
+	if p.check(scanner.PrivateIdent) {
+		return p.advance(), nil
+	}
+	return p.consume(scanner.Ident, msg)
+}
+
+// abstractMethod parses a method signature `abstract fn name(params);` which subclasses must implement
+func (p *Parser) abstractMethod() (*ast.MethodDeclStmt, error) {
+	p.advance()
+	p.advance()
+
+	fn, err := p.signature("method")
+	if err != nil {
+		return nil, err
+	}
+	if fn.Name.Type == scanner.PrivateIdent {
+		return nil, util.ReportErrorOnToken(fn.Name, "abstract methods can't be private")
+	}
+	if fn.Name.Lexeme == "init" {
+		return nil, util.ReportErrorOnToken(fn.Name, "'init' can't be abstract")
+	}
+
+	_, err = p.consume(scanner.Semicolon, "expected ';' after abstract method")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.MethodDeclStmt{
+		Func:     fn,
+		Abstract: true,
+	}, nil
+}
+
+// accessor parses a computed property `get name() { ... }` or `set name(value) { ... }`
+func (p *Parser) accessor() (*ast.MethodDeclStmt, error) {
+	keyword := p.advance()
+	getter := keyword.Lexeme == "get"
+
+	fn, err := p.function(keyword.Lexeme + "ter")
+	if err != nil {
+		return nil, err
+	}
 
-		for p.match(scanner.Comma) {
-			param, err = p.consume(scanner.Ident, "expected parameter name")
+	if getter && len(fn.Params) != 0 {
+		return nil, util.ReportErrorOnToken(fn.Name, "getter '%v' can't have parameters", fn.Name.Lexeme)
+	}
+	if !getter && (len(fn.Params) != 1 || fn.Variadic || fn.Defaults[0] != nil) {
+		return nil, util.ReportErrorOnToken(fn.Name, "setter '%v' must have exactly one parameter", fn.Name.Lexeme)
+	}
+
+	return &ast.MethodDeclStmt{
+		Func:   fn,
+		Getter: getter,
+		Setter: !getter,
+	}, nil
+}
+
+// params parses a parameter list, defaults has a nil entry for every parameter without a default value.
+// variadic is true when the last parameter is `...rest`
+func (p *Parser) params() ([]scanner.Token, []ast.Expr, bool, error) {
+
+	_, err := p.consume(scanner.LeftParen, "expected '(' before parameter list")
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	params := make([]scanner.Token, 0)
+	defaults := make([]ast.Expr, 0)
+	variadic := false
+	if !p.check(scanner.RightParen) {
+
+		for {
+			variadic = p.match(scanner.Ellipsis)
+			param, err := p.consume(scanner.Ident, "expected parameter name")
 			if err != nil {
-				return nil, err
+				return nil, nil, false, err
+			}
+
+			var value ast.Expr
+			if variadic {
+				if p.check(scanner.Comma) {
+					return nil, nil, false, util.ReportErrorOnToken(param, "rest parameter must be the last one")
+				}
+			} else if p.match(scanner.Equal) {
+				value, err = p.expression()
+				if err != nil {
+					return nil, nil, false, err
+				}
+			} else if len(defaults) > 0 && defaults[len(defaults)-1] != nil {
+				return nil, nil, false, util.ReportErrorOnToken(param, "parameter without default value can't follow parameters with defaults")
 			}
 			params = append(params, param)
+			defaults = append(defaults, value)
+
+			if !p.match(scanner.Comma) {
+				break
+			}
 		}
 	}
 
 	_, err = p.consume(scanner.RightParen, "expected ')' after parameter list")
-	return params, err
+	return params, defaults, variadic, err
 }
 
 func (p *Parser) function(kind string) (*ast.FuncDeclStmt, error) {
-	name, err := p.consume(scanner.Ident, "expected "+kind+" name")
+	fn, err := p.signature(kind)
 	if err != nil {
 		return nil, err
 	}
 
-	params, err := p.params()
+	err = p.body(fn, kind)
 	if err != nil {
 		return nil, err
 	}
+	return fn, nil
+}
 
-	body, err := p.functionBody(p.peek(), kind)
+// signature parses the name and the parameters of a function, without its body
+func (p *Parser) signature(kind string) (*ast.FuncDeclStmt, error) {
+	var name scanner.Token
+	var err error
+	if kind == "method" {
+		name, err = p.memberName("expected method name")
+	} else {
+		name, err = p.consume(scanner.Ident, "expected "+kind+" name")
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if p.prev().Type != scanner.RightBrace {
-		_, err := p.consume(scanner.Semicolon, "expected ';' after function expression")
-		if err != nil {
-			return nil, err
-		}
+	params, defaults, variadic, err := p.params()
+	if err != nil {
+		return nil, err
 	}
 
 	return &ast.FuncDeclStmt{
-		Name:   name,
-		Params: params,
-		Body:   body,
+		Name:     name,
+		Params:   params,
+		Defaults: defaults,
+		Variadic: variadic,
 	}, nil
+}
+
+// body parses the body of a function whose signature is already parsed
+func (p *Parser) body(fn *ast.FuncDeclStmt, kind string) error {
+	// arrow bodies may end with '}' too, e.g. `fn f() => {"key": 1};`
+	arrow := p.check(scanner.Arrow)
+
+	body, err := p.functionBody(p.peek(), kind)
+	if err != nil {
+		return err
+	}
+
+	if arrow {
+		_, err := p.consume(scanner.Semicolon, "expected ';' after function expression")
+		if err != nil {
+			return err
+		}
+	}
 
+	fn.Body = body
+	return nil
 }
 
 func (p *Parser) lambdaFunction() (ast.Expr, error) {
-	params, err := p.params()
+	params, defaults, variadic, err := p.params()
 	if err != nil {
 		return nil, err
 	}
@@ -756,8 +2002,10 @@ func (p *Parser) lambdaFunction() (ast.Expr, error) {
 	body, err := p.functionBody(p.peek(), "lambda")
 
 	return &ast.LambdaExpr{
-		Params: params,
-		Body:   body,
+		Params:   params,
+		Defaults: defaults,
+		Variadic: variadic,
+		Body:     body,
 	}, nil
 }
 
@@ -790,6 +2038,10 @@ func (p *Parser) functionBody(name scanner.Token, kind string) ([]ast.Stmt, erro
 }
 
 func (p *Parser) varDeclaration() (ast.Stmt, error) {
+	if p.check(scanner.LeftBracket) || p.check(scanner.LeftBrace) {
+		return p.destructuringDeclaration()
+	}
+
 	name, err := p.consume(scanner.Ident, "expected variable name")
 	if err != nil {
 		return nil, err
@@ -814,6 +2066,35 @@ func (p *Parser) varDeclaration() (ast.Stmt, error) {
 	}, nil
 }
 
+// destructuringDeclaration parses `let [a, b] = value;` and `let {a, b} = value;`
+func (p *Parser) destructuringDeclaration() (ast.Stmt, error) {
+	keyword := p.prev()
+	pattern, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(scanner.Equal, "expected '=' after destructuring pattern")
+	if err != nil {
+		return nil, err
+	}
+
+	init, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(scanner.Semicolon, "expected ';' after variable declaration")
+	if err != nil {
+		return nil, err
+	}
+	return &ast.DestructureStmt{
+		Keyword: keyword,
+		Pattern: pattern,
+		Init:    init,
+	}, nil
+}
+
 func (p *Parser) expressionStatement() (ast.Stmt, error) {
 	expr, err := p.expression()
 	if err != nil {
@@ -840,9 +2121,15 @@ func (p *Parser) sync() {
 			return
 		}
 
+		// statements starting with contextual keywords
+		if p.isMatchStatement() || p.isTraitDeclaration() {
+			return
+		}
+
 		switch p.peek().Type {
 		case scanner.Class, scanner.Func, scanner.Var, scanner.For,
-			scanner.If, scanner.While, scanner.Return:
+			scanner.If, scanner.While, scanner.Return, scanner.Break, scanner.Continue,
+			scanner.Throw, scanner.Try, scanner.Import, scanner.Export:
 			return
 		}
 
@@ -28,13 +28,18 @@ func (r *Resolver) VisitCallExpr(expr *ast.CallExpr) (any, error) {
 	return nil, nil
 }
 
+func (r *Resolver) VisitSpreadExpr(expr *ast.SpreadExpr) (any, error) {
+	r.resolveExpr(expr.Value)
+	return nil, nil
+}
+
 func (r *Resolver) VisitGroupingExpr(expr *ast.GroupingExpr) (any, error) {
 	r.resolveExpr(expr.Expression)
 	return nil, nil
 }
 
 func (r *Resolver) VisitLambdaExpr(expr *ast.LambdaExpr) (any, error) {
-	r.resolveFunction(expr.Params, expr.Body, functionFunc)
+	r.resolveFunction(expr.Params, expr.Defaults, expr.Body, functionFunc)
 	return nil, nil
 }
 
@@ -67,15 +72,38 @@ func (r *Resolver) VisitVarExpr(expr *ast.VarExpr) (any, error) {
 
 func (r *Resolver) VisitGetExpr(expr *ast.GetExpr) (any, error) {
 	r.resolveExpr(expr.Object)
+	r.resolvePrivate(expr, expr.Object, expr.Name)
 	return nil, nil
 }
 
 func (r *Resolver) VisitSetExpr(expr *ast.SetExpr) (any, error) {
 	r.resolveExpr(expr.Value)
 	r.resolveExpr(expr.Object)
+	r.resolvePrivate(expr, expr.Object, expr.Name)
 	return nil, nil
 }
 
+// resolvePrivate checks that a private member is accessed through `this`
+// inside the class declaring it, and tells the interpreter which class that is
+func (r *Resolver) resolvePrivate(access ast.Expr, object ast.Expr, name scanner.Token) {
+	if name.Type != scanner.PrivateIdent {
+		return
+	}
+	if r.classDecl == nil {
+		r.addError(util.ReportErrorOnToken(name, "private member '%v' can only be accessed inside its class", name.Lexeme))
+		return
+	}
+	if _, ok := object.(*ast.ThisExpr); !ok {
+		r.addError(util.ReportErrorOnToken(name, "private member '%v' can only be accessed through 'this'", name.Lexeme))
+		return
+	}
+	if !r.privateNames[name.Lexeme] {
+		r.addError(util.ReportErrorOnToken(name, "private member '%v' is not declared in class '%v'", name.Lexeme, r.classDecl.Name.Lexeme))
+		return
+	}
+	r.interpreter.ResolvePrivate(access, r.classDecl)
+}
+
 func (r *Resolver) VisitThisExpr(expr *ast.ThisExpr) (any, error) {
 
 	if r.currentClass == classNone {
@@ -126,3 +154,44 @@ func (r *Resolver) VisitSuperExpr(expr *ast.SuperExpr) (any, error) {
 	r.resolveLocal(expr, expr.Keyword)
 	return nil, nil
 }
+
+func (r *Resolver) VisitListExpr(expr *ast.ListExpr) (any, error) {
+	for _, element := range expr.Elements {
+		r.resolveExpr(element)
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitMapExpr(expr *ast.MapExpr) (any, error) {
+	for idx := range expr.Keys {
+		r.resolveExpr(expr.Keys[idx])
+		r.resolveExpr(expr.Values[idx])
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitInterpolationExpr(expr *ast.InterpolationExpr) (any, error) {
+	for _, part := range expr.Parts {
+		r.resolveExpr(part)
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitCompoundAssignExpr(expr *ast.CompoundAssignExpr) (any, error) {
+	r.resolveExpr(expr.Target)
+	r.resolveExpr(expr.Value)
+	return nil, nil
+}
+
+func (r *Resolver) VisitConditionalExpr(expr *ast.ConditionalExpr) (any, error) {
+	r.resolveExpr(expr.Condition)
+	r.resolveExpr(expr.Then)
+	r.resolveExpr(expr.Else)
+	return nil, nil
+}
+
+func (r *Resolver) VisitDestructureExpr(expr *ast.DestructureExpr) (any, error) {
+	r.resolveExpr(expr.Value)
+	r.resolveExpr(expr.Target)
+	return nil, nil
+}
@@ -31,13 +31,25 @@ type Resolver struct {
 	scopes          stack.Stack[scopeMap]
 	currentFunction funcType
 	currentClass    classType
-	errs            []error
+	// classDecl is the innermost class being resolved, privateNames are its private members
+	classDecl    *ast.ClassDeclStmt
+	privateNames map[string]bool
+	loopDepth    int
+	// declarations has the class and trait declarations of every scope, other names map to nil,
+	// globalDeclarations has the ones of the global scope
+	declarations       stack.Stack[map[string]ast.Stmt]
+	globalDeclarations map[string]ast.Stmt
+	// resolved are the declarations the superclasses and traits of classes refer to
+	resolved map[*ast.VarExpr]ast.Stmt
+	errs     []error
 }
 
 func New(i *interpreter.Interpreter) *Resolver {
 	r := &Resolver{
-		interpreter: i,
-		errs:        make([]error, 0),
+		interpreter:        i,
+		globalDeclarations: make(map[string]ast.Stmt),
+		resolved:           make(map[*ast.VarExpr]ast.Stmt),
+		errs:               make([]error, 0),
 	}
 	return r
 }
@@ -58,32 +70,44 @@ func (r *Resolver) resolveExpr(expr ast.Expr) {
 	expr.Accept(r)
 }
 
-func (r *Resolver) resolveFunction(params []scanner.Token, body []ast.Stmt, typ funcType) {
+func (r *Resolver) resolveFunction(params []scanner.Token, defaults []ast.Expr, body []ast.Stmt, typ funcType) {
 	enclosingFunction := r.currentFunction
+	enclosingLoopDepth := r.loopDepth
 	r.currentFunction = typ
+	// loops outside of the function can't be broken out of from within it
+	r.loopDepth = 0
 	r.beginScope()
-	for _, param := range params {
+	for idx, param := range params {
+		// defaults can refer to the parameters before them
+		if defaults[idx] != nil {
+			r.resolveExpr(defaults[idx])
+		}
 		r.declare(param)
 		r.define(param)
 	}
 	r.ResolveStatements(body)
 	r.endScope()
 	r.currentFunction = enclosingFunction
+	r.loopDepth = enclosingLoopDepth
 }
 
 func (r *Resolver) beginScope() {
 	scope := make(scopeMap)
 	r.scopes.Push(scope)
+	r.declarations.Push(make(map[string]ast.Stmt))
 }
 
 func (r *Resolver) endScope() {
 	r.scopes.Pop()
+	r.declarations.Pop()
 }
 
 func (r *Resolver) declare(name scanner.Token) {
 	const msg = "'%v' was already defined in this scope"
+	r.declarationScope()[name.Lexeme] = nil
 	if r.scopes.Empty() {
-		if r.interpreter.GlobalExists(name.Lexeme) {
+		// builtins can be hidden by globals of the script
+		if r.interpreter.GlobalExists(name.Lexeme) && !r.interpreter.IsBuiltin(name.Lexeme) {
 			r.addError(util.ReportErrorOnToken(name, msg, name.Lexeme))
 		}
 		return
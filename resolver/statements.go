@@ -1,7 +1,10 @@
 package resolver
 
 import (
+	"slices"
+
 	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/scanner"
 	"github.com/Valeron93/crafting-interpreters/util"
 )
 
@@ -14,7 +17,7 @@ func (r *Resolver) VisitFuncDeclStmt(stmt *ast.FuncDeclStmt) (any, error) {
 	r.declare(stmt.Name)
 	r.define(stmt.Name)
 
-	r.resolveFunction(stmt.Params, stmt.Body, functionFunc)
+	r.resolveFunction(stmt.Params, stmt.Defaults, stmt.Body, functionFunc)
 	return nil, nil
 }
 
@@ -59,15 +62,23 @@ func (r *Resolver) VisitVarStmt(stmt *ast.VarStmt) (any, error) {
 
 func (r *Resolver) VisitWhileStmt(stmt *ast.WhileStmt) (any, error) {
 	r.resolveExpr(stmt.Condition)
+	r.loopDepth++
 	r.resolveStmt(stmt.Body)
+	r.loopDepth--
+	if stmt.Increment != nil {
+		r.resolveExpr(stmt.Increment)
+	}
 	return nil, nil
 }
 
 func (r *Resolver) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 	enclosingClass := r.currentClass
+	enclosingDecl, enclosingPrivates := r.classDecl, r.privateNames
 	r.currentClass = classClass
+	r.classDecl, r.privateNames = stmt, r.privateMembers(stmt)
 	r.declare(stmt.Name)
 	r.define(stmt.Name)
+	r.declarationScope()[stmt.Name.Lexeme] = stmt
 
 	if stmt.Superclass != nil {
 		if stmt.Name.Lexeme == stmt.Superclass.Name.Lexeme {
@@ -75,6 +86,15 @@ func (r *Resolver) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 		}
 		r.currentClass = classSubclass
 		r.resolveExpr(stmt.Superclass)
+		r.lookUpDeclaration(stmt.Superclass)
+	}
+
+	for _, trait := range stmt.Traits {
+		r.resolveExpr(trait)
+		r.lookUpDeclaration(trait)
+	}
+	if len(stmt.Traits) > 0 && r.checkTraits(stmt) {
+		r.interpreter.ResolveTraits(stmt)
 	}
 
 	if stmt.Superclass != nil {
@@ -82,9 +102,37 @@ func (r *Resolver) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 		r.scopes.MustPeek()["super"] = true
 	}
 
+	// static fields are initialized in the scope of the class declaration, without `this`
+	fields := make(map[string]bool)
+	for _, field := range stmt.StaticFields {
+		r.checkField(field, fields)
+		if field.Init != nil {
+			r.resolveExpr(field.Init)
+		}
+	}
+
 	r.beginScope()
 	scope := r.scopes.MustPeek()
+	accessors := make(map[string]bool)
 	for _, method := range stmt.Methods {
+		r.checkMember(method, accessors)
+	}
+	r.checkAbstract(stmt)
+
+	// instance fields are initialized with `this` bound to the new instance
+	scope["this"] = true
+	for _, field := range stmt.Fields {
+		r.checkField(field, fields)
+		if field.Init != nil {
+			r.resolveExpr(field.Init)
+		}
+	}
+
+	for _, method := range stmt.Methods {
+		if method.Abstract {
+			continue
+		}
+
 		var declaration funcType
 		if !method.Static {
 			declaration = functionMethod
@@ -94,7 +142,7 @@ func (r *Resolver) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 			declaration = functionFunc
 			delete(scope, "this")
 		}
-		r.resolveFunction(method.Func.Params, method.Func.Body, declaration)
+		r.resolveFunction(method.Func.Params, method.Func.Defaults, method.Func.Body, declaration)
 	}
 
 	r.endScope()
@@ -104,12 +152,245 @@ func (r *Resolver) VisitClassDeclStmt(stmt *ast.ClassDeclStmt) (any, error) {
 	}
 
 	r.currentClass = enclosingClass
+	r.classDecl, r.privateNames = enclosingDecl, enclosingPrivates
 
 	return nil, nil
 }
 
+func (r *Resolver) VisitTraitDeclStmt(stmt *ast.TraitDeclStmt) (any, error) {
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	r.declarationScope()[stmt.Name.Lexeme] = stmt
+
+	// trait methods are resolved like methods of a class without superclass
+	enclosingClass := r.currentClass
+	enclosingDecl, enclosingPrivates := r.classDecl, r.privateNames
+	r.currentClass = classClass
+	r.classDecl, r.privateNames = nil, nil
+
+	names := make(map[string]bool)
+	for _, method := range slices.Concat(stmt.Methods, stmt.Required) {
+		if method.Name.Lexeme == "init" {
+			r.addError(util.ReportErrorOnToken(method.Name, "traits can't declare 'init'"))
+		}
+		if names[method.Name.Lexeme] {
+			r.addError(util.ReportErrorOnToken(method.Name, "method '%v' is already declared in this trait", method.Name.Lexeme))
+		}
+		names[method.Name.Lexeme] = true
+	}
+
+	r.beginScope()
+	r.scopes.MustPeek()["this"] = true
+	for _, method := range stmt.Methods {
+		r.resolveFunction(method.Params, method.Defaults, method.Body, functionMethod)
+	}
+	r.endScope()
+
+	r.currentClass = enclosingClass
+	r.classDecl, r.privateNames = enclosingDecl, enclosingPrivates
+	return nil, nil
+}
+
+// privateMembers collects the names of private fields and methods declared by a class
+func (r *Resolver) privateMembers(stmt *ast.ClassDeclStmt) map[string]bool {
+	names := make(map[string]bool)
+	for _, method := range stmt.Methods {
+		name := method.Func.Name
+		if name.Type != scanner.PrivateIdent {
+			continue
+		}
+		if names[name.Lexeme] {
+			r.addError(util.ReportErrorOnToken(name, "private member '%v' is already declared in this class", name.Lexeme))
+		}
+		names[name.Lexeme] = true
+	}
+	for _, field := range stmt.Fields {
+		if field.Name.Type == scanner.PrivateIdent {
+			if names[field.Name.Lexeme] {
+				r.addError(util.ReportErrorOnToken(field.Name, "private member '%v' is already declared in this class", field.Name.Lexeme))
+			}
+			names[field.Name.Lexeme] = true
+		}
+	}
+	return names
+}
+
+// checkField reports a field declared more than once in a class
+func (r *Resolver) checkField(field *ast.VarStmt, fields map[string]bool) {
+	if fields[field.Name.Lexeme] {
+		r.addError(util.ReportErrorOnToken(field.Name, "field '%v' is already declared in this class", field.Name.Lexeme))
+	}
+	fields[field.Name.Lexeme] = true
+}
+
+// checkMember reports a name used by both a method and a computed property of a class
+func (r *Resolver) checkMember(method *ast.MethodDeclStmt, accessors map[string]bool) {
+	name := method.Func.Name
+	accessor := method.Getter || method.Setter
+	if known, ok := accessors[name.Lexeme]; ok && known != accessor {
+		r.addError(util.ReportErrorOnToken(name, "'%v' is declared both as a method and as a property", name.Lexeme))
+	}
+	accessors[name.Lexeme] = accessor
+}
+
+// checkAbstract reports abstract methods declared more than once, or declared with a body too
+func (r *Resolver) checkAbstract(stmt *ast.ClassDeclStmt) {
+	abstract := make(map[string]bool)
+	for _, method := range stmt.Methods {
+		name := method.Func.Name
+		if !method.Abstract {
+			continue
+		}
+		if abstract[name.Lexeme] {
+			r.addError(util.ReportErrorOnToken(name, "abstract method '%v' is already declared in this class", name.Lexeme))
+		}
+		abstract[name.Lexeme] = true
+	}
+
+	for _, method := range stmt.Methods {
+		name := method.Func.Name
+		// accessors are reported by checkMember
+		accessor := method.Getter || method.Setter
+		if !method.Abstract && !accessor && abstract[name.Lexeme] {
+			r.addError(util.ReportErrorOnToken(name, "method '%v' is declared both abstract and with a body", name.Lexeme))
+		}
+	}
+}
+
 func (r *Resolver) VisitMethodDeclStmt(stmt *ast.MethodDeclStmt) (any, error) {
 	// TODO: look: interpreter/interpreter.go: VisitMethodDeclStmt
 
 	return nil, nil
 }
+
+func (r *Resolver) VisitBreakStmt(stmt *ast.BreakStmt) (any, error) {
+	if r.loopDepth == 0 {
+		r.addError(util.ReportErrorOnToken(stmt.Keyword, "'break' is allowed only inside loops"))
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitContinueStmt(stmt *ast.ContinueStmt) (any, error) {
+	if r.loopDepth == 0 {
+		r.addError(util.ReportErrorOnToken(stmt.Keyword, "'continue' is allowed only inside loops"))
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitThrowStmt(stmt *ast.ThrowStmt) (any, error) {
+	r.resolveExpr(stmt.Value)
+	return nil, nil
+}
+
+func (r *Resolver) VisitTryStmt(stmt *ast.TryStmt) (any, error) {
+	r.beginScope()
+	r.ResolveStatements(stmt.Body)
+	r.endScope()
+
+	if stmt.CatchName != nil {
+		r.beginScope()
+		r.declare(*stmt.CatchName)
+		r.define(*stmt.CatchName)
+		r.ResolveStatements(stmt.Catch)
+		r.endScope()
+	}
+
+	if stmt.Finally != nil {
+		r.beginScope()
+		r.ResolveStatements(stmt.Finally)
+		r.endScope()
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitImportStmt(stmt *ast.ImportStmt) (any, error) {
+	if stmt.Alias != nil {
+		r.declare(*stmt.Alias)
+		r.define(*stmt.Alias)
+	}
+
+	for _, name := range stmt.Names {
+		r.declare(name)
+		r.define(name)
+	}
+	return nil, nil
+}
+
+func (r *Resolver) VisitExportStmt(stmt *ast.ExportStmt) (any, error) {
+	if !r.scopes.Empty() {
+		r.addError(util.ReportErrorOnToken(stmt.Keyword, "export is allowed only at the top level of a module"))
+	}
+
+	r.resolveStmt(stmt.Decl)
+	return nil, nil
+}
+
+func (r *Resolver) VisitMatchStmt(stmt *ast.MatchStmt) (any, error) {
+	r.resolveExpr(stmt.Value)
+
+	for _, arm := range stmt.Arms {
+		r.beginScope()
+		r.resolvePattern(arm.Pattern)
+		if arm.Guard != nil {
+			r.resolveExpr(arm.Guard)
+		}
+		r.resolveStmt(arm.Body)
+		r.endScope()
+	}
+	return nil, nil
+}
+
+func (r *Resolver) resolvePattern(pattern ast.Pattern) {
+	switch pattern := pattern.(type) {
+	case *ast.BindingPattern:
+		r.declare(pattern.Name)
+		r.define(pattern.Name)
+
+	case *ast.ClassPattern:
+		r.resolveExpr(pattern.Class)
+		for _, field := range pattern.Fields {
+			r.declare(field)
+			r.define(field)
+		}
+
+	case *ast.ListPattern:
+		for _, element := range pattern.Elements {
+			r.resolvePattern(element)
+		}
+		if pattern.Rest != nil {
+			r.declare(*pattern.Rest)
+			r.define(*pattern.Rest)
+		}
+
+	case *ast.ObjectPattern:
+		for _, value := range pattern.Values {
+			r.resolvePattern(value)
+		}
+
+	case *ast.DefaultPattern:
+		// the default is evaluated before the names of its pattern are bound
+		r.resolveExpr(pattern.Default)
+		r.resolvePattern(pattern.Pattern)
+	}
+}
+
+func (r *Resolver) VisitDestructureStmt(stmt *ast.DestructureStmt) (any, error) {
+	r.resolveExpr(stmt.Init)
+	r.resolvePattern(stmt.Pattern)
+	return nil, nil
+}
+
+func (r *Resolver) VisitForInStmt(stmt *ast.ForInStmt) (any, error) {
+	r.resolveExpr(stmt.Iterable)
+
+	// the interpreter creates a new environment for every iteration,
+	// so closures in the body capture the value of that iteration
+	r.beginScope()
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	r.loopDepth++
+	r.resolveStmt(stmt.Body)
+	r.loopDepth--
+	r.endScope()
+	return nil, nil
+}
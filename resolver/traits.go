@@ -0,0 +1,124 @@
+package resolver
+
+import (
+	"slices"
+
+	"github.com/Valeron93/crafting-interpreters/ast"
+	"github.com/Valeron93/crafting-interpreters/util"
+)
+
+// declarationScope returns the declarations of the innermost scope
+func (r *Resolver) declarationScope() map[string]ast.Stmt {
+	if declarations, ok := r.declarations.Peek(); ok {
+		return declarations
+	}
+	return r.globalDeclarations
+}
+
+// lookUpDeclaration records the class or trait declaration name refers to,
+// which is nil if it refers to something else, e.g. an imported trait
+func (r *Resolver) lookUpDeclaration(name *ast.VarExpr) {
+	for idx := r.declarations.Count() - 1; idx >= 0; idx-- {
+		if decl, ok := r.declarations.GetIdx(idx)[name.Name.Lexeme]; ok {
+			r.resolved[name] = decl
+			return
+		}
+	}
+	r.resolved[name] = r.globalDeclarations[name.Name.Lexeme]
+}
+
+// checkTraits reports methods defined by more than one trait of a class which the class doesn't override,
+// and required methods of the traits the class doesn't implement. It returns false if the class has traits
+// or superclasses declared in other modules, the interpreter checks those classes when declaring them
+func (r *Resolver) checkTraits(stmt *ast.ClassDeclStmt) bool {
+	traits := make([]*ast.TraitDeclStmt, 0, len(stmt.Traits))
+	for _, name := range stmt.Traits {
+		trait, ok := r.resolved[name].(*ast.TraitDeclStmt)
+		if !ok {
+			return false
+		}
+		traits = append(traits, trait)
+	}
+
+	// members of the class override the methods of its traits
+	providers := make(map[string]*ast.TraitDeclStmt)
+	for idx, trait := range traits {
+		for _, method := range trait.Methods {
+			if declaresMember(stmt, method.Name.Lexeme) {
+				continue
+			}
+			if other, ok := providers[method.Name.Lexeme]; ok && other != trait {
+				r.addError(util.ReportErrorOnToken(stmt.Traits[idx].Name, "method '%v' is defined by both traits '%v' and '%v', class '%v' must override it",
+					method.Name.Lexeme, other.Name.Lexeme, trait.Name.Lexeme, stmt.Name.Lexeme))
+				continue
+			}
+			providers[method.Name.Lexeme] = trait
+		}
+	}
+
+	checked := true
+	for _, trait := range traits {
+		for _, method := range trait.Required {
+			if _, ok := providers[method.Name.Lexeme]; ok || declaresMethod(stmt, method.Name.Lexeme) {
+				continue
+			}
+
+			inherited, known := r.inheritsMethod(stmt, method.Name.Lexeme)
+			if !known {
+				checked = false
+			} else if !inherited {
+				r.addError(util.ReportErrorOnToken(stmt.Name, "class '%v' must implement method '%v' required by trait '%v'",
+					stmt.Name.Lexeme, method.Name.Lexeme, trait.Name.Lexeme))
+			}
+		}
+	}
+	return checked
+}
+
+// declaresMember reports whether the class declares a method or an accessor with the given name,
+// abstract methods don't count since a trait may implement them
+func declaresMember(stmt *ast.ClassDeclStmt, name string) bool {
+	return slices.ContainsFunc(stmt.Methods, func(method *ast.MethodDeclStmt) bool {
+		return method.Func.Name.Lexeme == name && !method.Abstract
+	})
+}
+
+// declaresMethod reports whether the class declares an instance method with the given name,
+// abstract methods count too, they have to be implemented by the subclasses
+func declaresMethod(stmt *ast.ClassDeclStmt, name string) bool {
+	return slices.ContainsFunc(stmt.Methods, func(method *ast.MethodDeclStmt) bool {
+		return method.Func.Name.Lexeme == name && !method.Static && !method.Getter && !method.Setter
+	})
+}
+
+// inheritsMethod reports whether a superclass of the class or one of their traits has the method.
+// known is false if that can't be told, because one of them is declared in another module
+func (r *Resolver) inheritsMethod(stmt *ast.ClassDeclStmt, name string) (inherited bool, known bool) {
+	visited := make(map[*ast.ClassDeclStmt]bool)
+	for stmt.Superclass != nil && !visited[stmt] {
+		visited[stmt] = true
+
+		superclass, ok := r.resolved[stmt.Superclass].(*ast.ClassDeclStmt)
+		if !ok {
+			return false, false
+		}
+		if declaresMethod(superclass, name) {
+			return true, true
+		}
+
+		for _, traitName := range superclass.Traits {
+			trait, ok := r.resolved[traitName].(*ast.TraitDeclStmt)
+			if !ok {
+				return false, false
+			}
+			// the superclass implements the methods its traits require
+			if slices.ContainsFunc(slices.Concat(trait.Methods, trait.Required), func(method *ast.FuncDeclStmt) bool {
+				return method.Name.Lexeme == name
+			}) {
+				return true, true
+			}
+		}
+		stmt = superclass
+	}
+	return false, true
+}
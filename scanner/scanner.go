@@ -1,8 +1,12 @@
 package scanner
 
 import (
+	"cmp"
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type Scanner struct {
@@ -13,6 +17,13 @@ type Scanner struct {
 	current int
 	line    int
 	column  int
+
+	// position of the first character of the token being scanned
+	startLine   int
+	startColumn int
+
+	// doc collects `///` comment lines until the next token
+	doc []string
 }
 
 func NewScanner(source string) Scanner {
@@ -23,6 +34,9 @@ func NewScanner(source string) Scanner {
 		current: 0,
 		line:    1,
 		column:  0,
+
+		startLine:   1,
+		startColumn: 1,
 	}
 }
 
@@ -31,13 +45,14 @@ func (s *Scanner) ScanTokens() ([]Token, []error) {
 	errors := []error{}
 	s.addToken(EOF)
 	for !s.isAtEnd() {
-		s.start = s.current
+		s.beginToken()
 		err := s.scanToken()
 		if err != nil {
 			errors = append(errors, err)
 		}
 	}
 
+	s.beginToken()
 	s.addToken(EOF)
 
 	return s.tokens, errors
@@ -47,6 +62,12 @@ func (s *Scanner) isAtEnd() bool {
 	return s.current >= len(s.source)
 }
 
+func (s *Scanner) beginToken() {
+	s.start = s.current
+	s.startLine = s.line
+	s.startColumn = s.column + 1
+}
+
 func (s *Scanner) addToken(typ TokenType) {
 	s.addTokenLiteral(typ, nil)
 }
@@ -57,15 +78,30 @@ func (s *Scanner) addTokenLiteral(typ TokenType, literal any) {
 		Type:    typ,
 		Lexeme:  text,
 		Literal: literal,
-		Line:    s.line,
-		Column:  s.column + 1,
+		Line:    s.startLine,
+		Column:  s.startColumn,
+		Doc:     strings.Join(s.doc, "\n"),
 	})
+	s.doc = nil
 }
 
+// tabWidth is the distance between tab stops, which columns after a tab are aligned to
+const tabWidth = 8
+
+// advance consumes a rune, columns are counted the way a terminal displays the line
 func (s *Scanner) advance() rune {
 	r := s.source[s.current]
 	s.current++
-	s.column++
+
+	switch r {
+	case '\n':
+		s.line++
+		s.column = 0
+	case '\t':
+		s.column += tabWidth - s.column%tabWidth
+	default:
+		s.column += runeWidth(r)
+	}
 	return r
 }
 
@@ -85,44 +121,102 @@ func (s *Scanner) scanToken() error {
 	case ',':
 		s.addToken(Comma)
 	case '.':
-		s.addToken(Dot)
+		if s.peek() == '.' && s.peekNext() == '.' {
+			s.advance()
+			s.advance()
+			s.addToken(Ellipsis)
+		} else {
+			s.addToken(Dot)
+		}
 	case '-':
-		s.addToken(Minus)
+		if s.match('-') {
+			s.addToken(MinusMinus)
+		} else if s.match('=') {
+			s.addToken(MinusEqual)
+		} else {
+			s.addToken(Minus)
+		}
 	case '+':
-		s.addToken(Plus)
+		if s.match('+') {
+			s.addToken(PlusPlus)
+		} else if s.match('=') {
+			s.addToken(PlusEqual)
+		} else {
+			s.addToken(Plus)
+		}
 	case ';':
 		s.addToken(Semicolon)
 	case '*':
-		s.addToken(Star)
+		if s.match('*') {
+			s.addToken(StarStar)
+		} else if s.match('=') {
+			s.addToken(StarEqual)
+		} else {
+			s.addToken(Star)
+		}
+	case '%':
+		if s.match('=') {
+			s.addToken(PercentEqual)
+		} else {
+			s.addToken(Percent)
+		}
+	case '&':
+		s.addToken(Ampersand)
+	case '|':
+		s.addToken(Pipe)
+	case '^':
+		s.addToken(Caret)
 	case '[':
 		s.addToken(LeftBracket)
 	case ']':
 		s.addToken(RightBracket)
 	case ':':
 		s.addToken(Colon)
+	case '?':
+		s.addToken(Question)
+	case '~':
+		if s.match('/') {
+			s.addToken(TildeSlash)
+		} else {
+			s.addToken(Tilde)
+		}
 
 	case '/':
 		if s.match('/') {
-			for s.peek() != '\n' && !s.isAtEnd() {
-				s.advance()
-			}
+			s.lineComment()
+		} else if s.match('*') {
+			return s.blockComment()
+		} else if s.match('=') {
+			s.addToken(SlashEqual)
 		} else {
 			s.addToken(Slash)
 		}
 
-	case ' ', '\r', '\t':
+	// advance keeps track of lines and columns
+	case ' ', '\r', '\t', '\n':
 		break
 
-	case '\n':
-		s.line++
-		s.column = 0
-
 	case '"':
 		err := s.string()
 		if err != nil {
 			return err
 		}
 
+	case '`':
+		err := s.rawString()
+		if err != nil {
+			return err
+		}
+
+	case '#':
+		if !isAlpha(s.peek()) {
+			return s.error("expected name after '#'")
+		}
+		for isAlphaNumeric(s.peek()) {
+			s.advance()
+		}
+		s.addToken(PrivateIdent)
+
 	case '!':
 		if s.match('=') {
 			s.addToken(BangEqual)
@@ -140,14 +234,18 @@ func (s *Scanner) scanToken() error {
 		}
 
 	case '<':
-		if s.match('=') {
+		if s.match('<') {
+			s.addToken(LessLess)
+		} else if s.match('=') {
 			s.addToken(LessEqual)
 		} else {
 			s.addToken(Less)
 		}
 
 	case '>':
-		if s.match('=') {
+		if s.match('>') {
+			s.addToken(GreaterGreater)
+		} else if s.match('=') {
 			s.addToken(GreaterEqual)
 		} else {
 			s.addToken(Greater)
@@ -178,7 +276,7 @@ func (s *Scanner) match(expected rune) bool {
 		return false
 	}
 
-	s.current++
+	s.advance()
 	return true
 }
 
@@ -197,84 +295,405 @@ func (s *Scanner) peekNext() rune {
 	return s.source[s.current+1]
 }
 
+// lineComment skips a `//` comment, the text of `///` doc comments is kept for the next token
+func (s *Scanner) lineComment() {
+	doc := s.peek() == '/' && s.peekNext() != '/'
+
+	for s.peek() != '\n' && !s.isAtEnd() {
+		s.advance()
+	}
+
+	if doc {
+		text := string(s.source[s.start+3 : s.current])
+		s.doc = append(s.doc, strings.TrimPrefix(strings.TrimRight(text, " \t\r"), " "))
+	}
+}
+
+// blockComment skips a `/* */` comment, block comments can be nested
+func (s *Scanner) blockComment() error {
+	depth := 1
+
+	for depth > 0 {
+		if s.isAtEnd() {
+			return s.error("unterminated block comment")
+		}
+
+		c := s.advance()
+		switch {
+		case c == '/' && s.peek() == '*':
+			s.advance()
+			depth++
+		case c == '*' && s.peek() == '/':
+			s.advance()
+			depth--
+		}
+	}
+	return nil
+}
+
+// string scans a string literal after its opening quote.
+// `"""` strings span lines, the line breaks after the opening and before the closing quotes
+// are not part of the string and the indentation common to all lines is removed.
 func (s *Scanner) string() error {
+	start, startLine, startColumn := s.start, s.startLine, s.startColumn
 	var value []rune
-	escaped := false
+	var parts []InterpolationPart
+	var escapeErr error
+
+	multiline := s.peek() == '"' && s.peekNext() == '"'
+	indent := 0
+	if multiline {
+		s.advance()
+		s.advance()
+		indent = s.multilineIndent()
+		if s.blankUntil('\n') {
+			s.skipBlank()
+			s.advance()
+			s.skipIndent(indent)
+		}
+	}
 
 	for !s.isAtEnd() {
 		c := s.peek()
+		if multiline && s.closingQuotes() || !multiline && c == '"' {
+			break
+		}
 
-		if escaped {
-			switch c {
-			case '"':
-				value = append(value, '"')
-			case 'n':
-				value = append(value, '\n')
-			case 't':
-				value = append(value, '\t')
-			case 'r':
-				value = append(value, '\r')
-			case '\\':
-				value = append(value, '\\')
-			default:
-				value = append(value, '\\', c)
+		switch {
+		case c == '\\':
+			r, err := s.escape()
+			if err != nil {
+				// the rest of the string is still scanned, so it isn't mistaken for code
+				escapeErr = cmp.Or(escapeErr, err)
 			}
-			escaped = false
-		} else {
-			if c == '"' {
-				break
+			value = append(value, r)
+
+		case c == '$' && s.peekNext() == '{':
+			s.advance()
+			s.advance()
+			tokens, err := s.interpolation()
+			if err != nil {
+				return err
 			}
-			if c == '\\' {
-				escaped = true
-			} else {
-				if c == '\n' {
-					s.line++
-				}
-				value = append(value, c)
+			parts = append(parts,
+				InterpolationPart{Text: string(value)},
+				InterpolationPart{Tokens: tokens},
+			)
+			value = nil
+
+		case c == '\n' && multiline:
+			s.advance()
+			if s.closingLine() {
+				s.skipBlank()
+				break
 			}
-		}
+			value = append(value, '\n')
+			s.skipIndent(indent)
 
-		s.advance()
+		default:
+			value = append(value, s.advance())
+		}
 	}
 
+	s.start, s.startLine, s.startColumn = start, startLine, startColumn
 	if s.isAtEnd() {
 		return s.error("unterminated string")
 	}
 	s.advance()
+	if multiline {
+		s.advance()
+		s.advance()
+	}
+
+	if escapeErr != nil {
+		return escapeErr
+	}
+
+	if parts != nil {
+		parts = append(parts, InterpolationPart{Text: string(value)})
+		s.addTokenLiteral(Interpolation, parts)
+		return nil
+	}
 
 	s.addTokenLiteral(String, string(value))
 	return nil
 }
 
+// escape scans an escape sequence of a string, starting at the backslash
+func (s *Scanner) escape() (rune, error) {
+	line, column := s.line, s.column+1
+	s.advance()
+	if s.isAtEnd() {
+		return 0, nil
+	}
+
+	c := s.advance()
+	switch c {
+	case '"', '\\', '$':
+		return c, nil
+	case 'n':
+		return '\n', nil
+	case 't':
+		return '\t', nil
+	case 'r':
+		return '\r', nil
+	case 'x':
+		return s.hexEscape(line, column)
+	case 'u':
+		return s.unicodeEscape(line, column)
+	}
+	return c, s.errorAt(line, column, fmt.Sprintf("unknown escape sequence '\\%c'", c))
+}
+
+// hexEscape scans the two hex digits of `\xFF`, the value is the code point of the character
+func (s *Scanner) hexEscape(line int, column int) (rune, error) {
+	if !isHexDigit(s.peek()) || !isHexDigit(s.peekNext()) {
+		return 0, s.errorAt(line, column, "escape sequence '\\x' must be followed by two hex digits")
+	}
+
+	value, _ := strconv.ParseUint(string([]rune{s.advance(), s.advance()}), 16, 8)
+	return rune(value), nil
+}
+
+// unicodeEscape scans `\u{1F600}`, a unicode code point of 1 to 6 hex digits
+func (s *Scanner) unicodeEscape(line int, column int) (rune, error) {
+	const msg = "escape sequence '\\u' must be followed by 1 to 6 hex digits in braces, e.g. '\\u{1F600}'"
+	if !s.match('{') {
+		return 0, s.errorAt(line, column, msg)
+	}
+
+	digits := make([]rune, 0, 6)
+	for isHexDigit(s.peek()) {
+		digits = append(digits, s.advance())
+	}
+
+	if len(digits) == 0 || len(digits) > 6 || !s.match('}') {
+		return 0, s.errorAt(line, column, msg)
+	}
+
+	value, _ := strconv.ParseUint(string(digits), 16, 32)
+	if !utf8.ValidRune(rune(value)) {
+		return 0, s.errorAt(line, column, fmt.Sprintf("escape sequence '\\u{%v}' is not a valid unicode code point", string(digits)))
+	}
+	return rune(value), nil
+}
+
+// rawString scans a string between backticks, it may span lines and has no escape sequences
+func (s *Scanner) rawString() error {
+	for !s.isAtEnd() && s.peek() != '`' {
+		s.advance()
+	}
+
+	if s.isAtEnd() {
+		return s.error("unterminated raw string")
+	}
+	s.advance()
+
+	s.addTokenLiteral(String, string(s.source[s.start+1:s.current-1]))
+	return nil
+}
+
+func (s *Scanner) closingQuotes() bool {
+	return s.current+2 < len(s.source) && string(s.source[s.current:s.current+3]) == `"""`
+}
+
+// blankUntil reports whether there is only whitespace before the next end rune on the line
+func (s *Scanner) blankUntil(end rune) bool {
+	idx := s.skipBlankFrom(s.current)
+	return idx < len(s.source) && s.source[idx] == end
+}
+
+// closingLine reports whether there is only whitespace before the closing `"""` on the line
+func (s *Scanner) closingLine() bool {
+	idx := s.skipBlankFrom(s.current)
+	return idx+2 < len(s.source) && string(s.source[idx:idx+3]) == `"""`
+}
+
+func (s *Scanner) skipBlankFrom(idx int) int {
+	for idx < len(s.source) && (s.source[idx] == ' ' || s.source[idx] == '\t' || s.source[idx] == '\r') {
+		idx++
+	}
+	return idx
+}
+
+func (s *Scanner) skipBlank() {
+	for s.peek() == ' ' || s.peek() == '\t' || s.peek() == '\r' {
+		s.advance()
+	}
+}
+
+// skipIndent skips up to indent whitespace characters at the start of a line
+func (s *Scanner) skipIndent(indent int) {
+	for range indent {
+		if s.peek() != ' ' && s.peek() != '\t' {
+			return
+		}
+		s.advance()
+	}
+}
+
+// multilineIndent returns the number of whitespace characters all lines of a `"""` string start with,
+// ignoring blank lines and the line after the opening quotes
+func (s *Scanner) multilineIndent() int {
+	end := s.current
+	for end < len(s.source) && string(s.source[end:min(end+3, len(s.source))]) != `"""` {
+		if s.source[end] == '\\' {
+			end++
+		}
+		end++
+	}
+
+	lines := strings.Split(string(s.source[s.current:min(end, len(s.source))]), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.TrimSpace(trimmed) == "" {
+			continue
+		}
+		if width := len(line) - len(trimmed); indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	return max(indent, 0)
+}
+
+// interpolation scans the tokens of an expression embedded in a string
+// up to the matching '}'. The tokens are wrapped in EOF tokens,
+// the same way ScanTokens does it, so they can be given to the parser.
+func (s *Scanner) interpolation() ([]Token, error) {
+	outer := s.tokens
+	defer func() {
+		s.tokens = outer
+	}()
+
+	line, column := s.line, s.column+1
+	s.tokens = []Token{}
+	s.addToken(EOF)
+
+	depth := 0
+	for !s.isAtEnd() {
+		s.beginToken()
+		if s.peek() == '}' && depth == 0 {
+			s.advance()
+			s.addToken(EOF)
+			return s.tokens, nil
+		}
+
+		count := len(s.tokens)
+		if err := s.scanToken(); err != nil {
+			return nil, err
+		}
+
+		// whitespace and comments don't produce tokens
+		if len(s.tokens) == count {
+			continue
+		}
+
+		if last := s.tokens[len(s.tokens)-1]; last.Type == LeftBrace {
+			depth++
+		} else if last.Type == RightBrace {
+			depth--
+		}
+	}
+
+	return nil, s.errorAt(line, column, "unterminated interpolation in string")
+}
+
+// error reports msg at the start of the token being scanned
 func (s *Scanner) error(msg string) error {
-	return fmt.Errorf("%v:%v: %v", s.line, s.column+1, msg)
+	return s.errorAt(s.startLine, s.startColumn, msg)
+}
+
+func (s *Scanner) errorAt(line int, column int, msg string) error {
+	return fmt.Errorf("%v:%v: %v", line, column, msg)
 }
 
 func isDigit(r rune) bool {
 	return r >= '0' && r <= '9'
 }
 
+// prefixedNumber scans an integer literal written in base after its `0x`, `0b` or `0o` prefix
+func (s *Scanner) prefixedNumber(base int, name string, isBaseDigit func(rune) bool) error {
+	prefix := string([]rune{'0', s.advance()})
+
+	if s.peek() == '_' {
+		return s.errorAt(s.line, s.column+1, "'_' must separate digits in number literal")
+	}
+	if !isBaseDigit(s.peek()) {
+		return s.error(fmt.Sprintf("%v literal has no digits after '%v'", name, prefix))
+	}
+
+	if err := s.digits(isBaseDigit); err != nil {
+		return err
+	}
+
+	if next := s.peek(); isAlphaNumeric(next) {
+		return s.errorAt(s.line, s.column+1, fmt.Sprintf("invalid digit '%c' in %v literal", next, name))
+	}
+
+	text := string(s.source[s.start:s.current])
+	value, err := strconv.ParseInt(strings.ReplaceAll(text[2:], "_", ""), base, 64)
+	if err != nil {
+		return s.error(fmt.Sprintf("%v literal '%v' is out of range for an int", name, text))
+	}
+
+	s.addTokenLiteral(Number, value)
+	return nil
+}
+
+// digits scans a run of digits, which can be separated by single '_' characters
+func (s *Scanner) digits(isDigit func(rune) bool) error {
+	for isDigit(s.peek()) || s.peek() == '_' {
+		if s.advance() == '_' && !isDigit(s.peek()) {
+			return s.errorAt(s.line, s.column, "'_' must separate digits in number literal")
+		}
+	}
+	return nil
+}
+
+func isHexDigit(r rune) bool {
+	return isDigit(r) || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')
+}
+
+func isBinaryDigit(r rune) bool {
+	return r == '0' || r == '1'
+}
+
+func isOctalDigit(r rune) bool {
+	return r >= '0' && r <= '7'
+}
+
+// isAlpha reports whether r can start an identifier, which are made of unicode letters, digits and '_'
 func isAlpha(r rune) bool {
-	return (r >= 'a' && r <= 'z') ||
-		(r >= 'A' && r <= 'Z') ||
-		(r == '_')
+	return unicode.IsLetter(r) || r == '_'
 }
 
 func isAlphaNumeric(r rune) bool {
-	return isAlpha(r) || isDigit(r)
+	return isAlpha(r) || unicode.IsDigit(r)
 }
 
 func (s *Scanner) number() error {
+	if s.source[s.start] == '0' {
+		switch s.peek() {
+		case 'x', 'X':
+			return s.prefixedNumber(16, "hex", isHexDigit)
+		case 'b', 'B':
+			return s.prefixedNumber(2, "binary", isBinaryDigit)
+		case 'o', 'O':
+			return s.prefixedNumber(8, "octal", isOctalDigit)
+		}
+	}
+
 	// Integer part
-	for isDigit(s.peek()) {
-		s.advance()
+	if err := s.digits(isDigit); err != nil {
+		return err
 	}
 
 	// Fractional part
 	if s.peek() == '.' && isDigit(s.peekNext()) {
 		s.advance() // consume '.'
-		for isDigit(s.peek()) {
-			s.advance()
+		if err := s.digits(isDigit); err != nil {
+			return err
 		}
 	}
 
@@ -290,12 +709,23 @@ func (s *Scanner) number() error {
 			return s.error("invalid scientific notation: missing digits after exponent")
 		}
 
-		for isDigit(s.peek()) {
-			s.advance()
+		if err := s.digits(isDigit); err != nil {
+			return err
+		}
+	}
+
+	valueStr := strings.ReplaceAll(string(s.source[s.start:s.current]), "_", "")
+
+	// numbers without fraction and exponent are integers
+	if !strings.ContainsAny(valueStr, ".eE") {
+		value, err := strconv.ParseInt(valueStr, 10, 64)
+		if err != nil {
+			return s.error("invalid integer: " + err.Error())
 		}
+		s.addTokenLiteral(Number, value)
+		return nil
 	}
 
-	valueStr := string(s.source[s.start:s.current])
 	value, err := strconv.ParseFloat(valueStr, 64)
 	if err != nil {
 		return s.error("invalid number: " + err.Error())
@@ -56,25 +56,75 @@ const (
 
 	Static
 	Colon
+
+	Break
+	Continue
+
+	Throw
+	Try
+	Catch
+	Finally
+
+	Import
+	Export
+
+	Interpolation
+	TildeSlash
+
+	Percent
+	StarStar
+	Ampersand
+	Pipe
+	Caret
+	Tilde
+	LessLess
+	GreaterGreater
+
+	PlusEqual
+	MinusEqual
+	StarEqual
+	SlashEqual
+	PercentEqual
+	PlusPlus
+	MinusMinus
+
+	Question
+	Ellipsis
+	PrivateIdent
 )
 
 var keywords = map[string]TokenType{
-	"and":    And,
-	"class":  Class,
-	"else":   Else,
-	"false":  False,
-	"for":    For,
-	"fn":     Func,
-	"if":     If,
-	"null":   Nil,
-	"or":     Or,
-	"return": Return,
-	"super":  Super,
-	"this":   This,
-	"true":   True,
-	"let":    Var,
-	"while":  While,
-	"static": Static,
+	"and":      And,
+	"class":    Class,
+	"else":     Else,
+	"false":    False,
+	"for":      For,
+	"fn":       Func,
+	"if":       If,
+	"null":     Nil,
+	"or":       Or,
+	"return":   Return,
+	"super":    Super,
+	"this":     This,
+	"true":     True,
+	"let":      Var,
+	"while":    While,
+	"static":   Static,
+	"break":    Break,
+	"continue": Continue,
+	"throw":    Throw,
+	"try":      Try,
+	"catch":    Catch,
+	"finally":  Finally,
+	"import":   Import,
+	"export":   Export,
+}
+
+// InterpolationPart is a piece of an interpolated string literal:
+// either Text, or Tokens of an expression embedded with "${...}"
+type InterpolationPart struct {
+	Text   string
+	Tokens []Token
 }
 
 type Token struct {
@@ -83,6 +133,8 @@ type Token struct {
 	Literal any
 	Line    int
 	Column  int
+	// Doc is the text of the `///` comments right before the token
+	Doc string
 }
 
 func (t Token) String() string {
@@ -51,11 +51,39 @@ func _() {
 	_ = x[EOF-40]
 	_ = x[Static-41]
 	_ = x[Colon-42]
+	_ = x[Break-43]
+	_ = x[Continue-44]
+	_ = x[Throw-45]
+	_ = x[Try-46]
+	_ = x[Catch-47]
+	_ = x[Finally-48]
+	_ = x[Import-49]
+	_ = x[Export-50]
+	_ = x[Interpolation-51]
+	_ = x[TildeSlash-52]
+	_ = x[Percent-53]
+	_ = x[StarStar-54]
+	_ = x[Ampersand-55]
+	_ = x[Pipe-56]
+	_ = x[Caret-57]
+	_ = x[Tilde-58]
+	_ = x[LessLess-59]
+	_ = x[GreaterGreater-60]
+	_ = x[PlusEqual-61]
+	_ = x[MinusEqual-62]
+	_ = x[StarEqual-63]
+	_ = x[SlashEqual-64]
+	_ = x[PercentEqual-65]
+	_ = x[PlusPlus-66]
+	_ = x[MinusMinus-67]
+	_ = x[Question-68]
+	_ = x[Ellipsis-69]
+	_ = x[PrivateIdent-70]
 }
 
-const _TokenType_name = "LeftParenRightParenLeftBraceRightBraceLeftBracketRightBracketCommaDotMinusPlusSemicolonSlashStarBangBangEqualEqualEqualEqualGreaterGreaterEqualLessLessEqualArrowIdentStringNumberAndClassElseFalseFuncForIfNilOrReturnSuperThisTrueVarWhileEOFStaticColon"
+const _TokenType_name = "LeftParenRightParenLeftBraceRightBraceLeftBracketRightBracketCommaDotMinusPlusSemicolonSlashStarBangBangEqualEqualEqualEqualGreaterGreaterEqualLessLessEqualArrowIdentStringNumberAndClassElseFalseFuncForIfNilOrReturnSuperThisTrueVarWhileEOFStaticColonBreakContinueThrowTryCatchFinallyImportExportInterpolationTildeSlashPercentStarStarAmpersandPipeCaretTildeLessLessGreaterGreaterPlusEqualMinusEqualStarEqualSlashEqualPercentEqualPlusPlusMinusMinusQuestionEllipsisPrivateIdent"
 
-var _TokenType_index = [...]uint8{0, 9, 19, 28, 38, 49, 61, 66, 69, 74, 78, 87, 92, 96, 100, 109, 114, 124, 131, 143, 147, 156, 161, 166, 172, 178, 181, 186, 190, 195, 199, 202, 204, 207, 209, 215, 220, 224, 228, 231, 236, 239, 245, 250}
+var _TokenType_index = [...]uint16{0, 9, 19, 28, 38, 49, 61, 66, 69, 74, 78, 87, 92, 96, 100, 109, 114, 124, 131, 143, 147, 156, 161, 166, 172, 178, 181, 186, 190, 195, 199, 202, 204, 207, 209, 215, 220, 224, 228, 231, 236, 239, 245, 250, 255, 263, 268, 271, 276, 283, 289, 295, 308, 318, 325, 333, 342, 346, 351, 356, 364, 378, 387, 397, 406, 416, 428, 436, 446, 454, 462, 474}
 
 func (i TokenType) String() string {
 	if i < 0 || i >= TokenType(len(_TokenType_index)-1) {
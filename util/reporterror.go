@@ -6,11 +6,25 @@ import (
 	"github.com/Valeron93/crafting-interpreters/scanner"
 )
 
+// Error is an error that happened at a known location in the source code
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v:%v: %s", e.Line, e.Column, e.Message)
+}
+
 func ReportErrorOnToken(token scanner.Token, format string, args ...any) error {
 	return ReportErrorOnLineAndColumn(token.Line, token.Column, format, args...)
 }
 
 func ReportErrorOnLineAndColumn(line int, column int, format string, args ...any) error {
-	end := fmt.Sprintf(format, args...)
-	return fmt.Errorf("%v:%v: %s", line, column, end)
+	return &Error{
+		Line:    line,
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	}
 }